@@ -0,0 +1,137 @@
+// Command phonebackfill parses the phone numbers of contacts saved before
+// numbers were stored in E.164, reading national numbers in the contact's
+// country or DEFAULT_PHONE_REGION. Numbers that do not parse keep only
+// their digits, as they would on save. Contacts are processed in id order
+// and in batches, so it can be stopped and rerun at any time. It uses the
+// same DB_* environment as the server:
+//
+//	go run ./cmd/phonebackfill -batch 1000
+package main
+
+import (
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/phone"
+	"address-book-server/postal"
+	"address-book-server/utils"
+
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func main() {
+	batch := flag.Int("batch", 500, "contacts per transaction")
+	all := flag.Bool("all", false, "reparse numbers that were already parsed")
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	flag.Parse()
+
+	logger.InitLogger()
+	_ = godotenv.Load()
+
+	if *batch < 1 {
+		fail(fmt.Errorf("-batch must be positive"))
+	}
+
+	db := utils.Connect()
+	utils.PerformMigration(db)
+
+	processed, parsed, err := backfill(db, *batch, *all, *dryRun)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("processed %d numbers, %d in E.164", processed, parsed)
+	if *dryRun {
+		fmt.Print(" (dry run, nothing written)")
+	}
+	fmt.Println()
+}
+
+func backfill(db *gorm.DB, batchSize int, all, dryRun bool) (int, int, error) {
+	var lastID uint64
+	processed, parsed := 0, 0
+
+	for {
+		var addresses []model.Address
+
+		query := db.Preload("Phones").Where("id > ?", lastID)
+		if !all {
+			query = query.Where("EXISTS (SELECT 1 FROM address_phones WHERE address_phones.address_id = addresses.id AND COALESCE(address_phones.digits, '') = '')")
+		}
+
+		if err := query.Order("id").Limit(batchSize).Find(&addresses).Error; err != nil {
+			return processed, parsed, err
+		}
+
+		if len(addresses) == 0 {
+			return processed, parsed, nil
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range addresses {
+				address := &addresses[i]
+				hint := region(address)
+				primaryE164 := ""
+
+				for _, p := range address.Phones {
+					e164, digits := "", phone.Digits(p.Phone)
+					if number, err := phone.Parse(p.Phone, hint); err == nil {
+						e164, digits = number.E164, strings.TrimPrefix(number.E164, "+")
+						parsed++
+					}
+					processed++
+
+					if p.IsPrimary {
+						primaryE164 = e164
+					}
+
+					if dryRun {
+						continue
+					}
+
+					if err := tx.Model(&model.AddressPhone{}).Where("id = ?", p.ID).UpdateColumns(map[string]interface{}{
+						"e164":   e164,
+						"digits": digits,
+					}).Error; err != nil {
+						return err
+					}
+				}
+
+				if dryRun {
+					continue
+				}
+
+				if err := tx.Model(&model.Address{}).Where("id = ?", address.ID).UpdateColumn("phone_e164", primaryE164).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return processed, parsed, err
+		}
+
+		lastID = addresses[len(addresses)-1].ID
+		logger.Log.Info("Backfilled phone numbers", zap.Uint64("last_id", lastID), zap.Int("count", len(addresses)))
+	}
+}
+
+// region matches the address service: the contact's country, else
+// DEFAULT_PHONE_REGION.
+func region(address *model.Address) string {
+	if country, ok := postal.LookupCountry(address.Country); ok {
+		return country.Alpha2
+	}
+	return strings.ToUpper(os.Getenv("DEFAULT_PHONE_REGION"))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "phonebackfill:", err)
+	os.Exit(1)
+}
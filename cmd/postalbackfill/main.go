@@ -0,0 +1,139 @@
+// Command postalbackfill fills the normalized country, state and postal
+// code columns of contacts written before they existed, or under an older
+// postal.Revision. Contacts are processed in id order and in batches, each
+// batch in its own transaction, so it can be stopped and rerun at any time.
+// It uses the same DB_* environment as the server:
+//
+//	go run ./cmd/postalbackfill -batch 1000
+package main
+
+import (
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/postal"
+	"address-book-server/utils"
+
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func main() {
+	batch := flag.Int("batch", 500, "contacts per transaction")
+	all := flag.Bool("all", false, "renormalize contacts that are already up to date")
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	flag.Parse()
+
+	logger.InitLogger()
+	_ = godotenv.Load()
+
+	if *batch < 1 {
+		fail(fmt.Errorf("-batch must be positive"))
+	}
+
+	db := utils.Connect()
+	utils.PerformMigration(db)
+
+	processed, changed, err := backfill(db, *batch, *all, *dryRun)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("processed %d contacts, %d changed", processed, changed)
+	if *dryRun {
+		fmt.Print(" (dry run, nothing written)")
+	}
+	fmt.Println()
+}
+
+func backfill(db *gorm.DB, batchSize int, all, dryRun bool) (int, int, error) {
+	var lastID uint64
+	processed, changed := 0, 0
+
+	for {
+		var addresses []model.Address
+
+		query := db.Preload("PostalAddresses").Where("id > ?", lastID)
+		if !all {
+			query = query.Where("postal_version IS NULL OR postal_version <> ?", postal.Revision)
+		}
+
+		if err := query.Order("id").Limit(batchSize).Find(&addresses).Error; err != nil {
+			return processed, changed, err
+		}
+
+		if len(addresses) == 0 {
+			return processed, changed, nil
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range addresses {
+				address := &addresses[i]
+				before := *address
+				before.PostalAddresses = append([]model.PostalAddress(nil), address.PostalAddresses...)
+
+				postal.ApplyCodes(address)
+				if codesChanged(&before, address) {
+					changed++
+				}
+
+				if dryRun {
+					continue
+				}
+
+				if err := tx.Model(&model.Address{}).Where("id = ?", address.ID).UpdateColumns(map[string]interface{}{
+					"country_code":   address.CountryCode,
+					"state_code":     address.StateCode,
+					"postal_code":    address.PostalCode,
+					"postal_version": address.PostalVersion,
+				}).Error; err != nil {
+					return err
+				}
+
+				for _, p := range address.PostalAddresses {
+					if err := tx.Model(&model.PostalAddress{}).Where("id = ?", p.ID).UpdateColumns(map[string]interface{}{
+						"country_code": p.CountryCode,
+						"state_code":   p.StateCode,
+						"postal_code":  p.PostalCode,
+					}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return processed, changed, err
+		}
+
+		processed += len(addresses)
+		lastID = addresses[len(addresses)-1].ID
+		logger.Log.Info("Backfilled postal codes", zap.Uint64("last_id", lastID), zap.Int("count", len(addresses)))
+	}
+}
+
+// codesChanged reports whether normalizing changed any stored code of the
+// contact or its postal addresses.
+func codesChanged(before, after *model.Address) bool {
+	if before.CountryCode != after.CountryCode || before.StateCode != after.StateCode || before.PostalCode != after.PostalCode {
+		return true
+	}
+
+	for i := range after.PostalAddresses {
+		b, a := before.PostalAddresses[i], after.PostalAddresses[i]
+		if b.CountryCode != a.CountryCode || b.StateCode != a.StateCode || b.PostalCode != a.PostalCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "postalbackfill:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,140 @@
+// Command postcodeimport converts the GeoNames postal code dump
+// (https://download.geonames.org/export/zip/, allCountries.txt or a single
+// country's file) into the postal code CSV the server loads from
+// POSTAL_CODES_FILE. States are matched to ISO 3166-2 subdivisions by name,
+// then by code; rows of countries without known subdivisions, or whose
+// state does not match, are skipped and counted:
+//
+//	go run ./cmd/postcodeimport -countries IN,US -o postcodes.csv IN.txt
+//	POSTAL_CODES_FILE=postcodes.csv go run .
+package main
+
+import (
+	"address-book-server/postal"
+
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// GeoNames columns used; the dump is tab separated with no header.
+const (
+	colCountry   = 0
+	colCode      = 1
+	colPlace     = 2
+	colAdminName = 3
+	colAdminCode = 4
+	colDistrict  = 5
+	minColumns   = 6
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default standard output)")
+	only := flag.String("countries", "", "comma separated alpha-2 codes to keep (default all)")
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		fail(fmt.Errorf("expected at most one input file"))
+	}
+
+	var in io.Reader = os.Stdin
+	if flag.NArg() == 1 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	countries := map[string]bool{}
+	for _, c := range strings.Split(*only, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			countries[strings.ToUpper(c)] = true
+		}
+	}
+
+	written, skipped, err := convert(in, w, countries)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote %d postal code places, skipped %d\n", written, skipped)
+}
+
+func convert(in io.Reader, out io.Writer, countries map[string]bool) (int, int, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	buffered := bufio.NewWriter(out)
+	w := csv.NewWriter(buffered)
+	if err := w.Write([]string{"country", "code", "city", "district", "state"}); err != nil {
+		return 0, 0, err
+	}
+
+	// The dump lists a place once per admin level on some codes.
+	seen := map[[4]string]bool{}
+	written, skipped := 0, 0
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < minColumns {
+			return written, skipped, fmt.Errorf("line %d: expected at least %d columns, got %d", line, minColumns, len(fields))
+		}
+
+		country := strings.ToUpper(fields[colCountry])
+		if len(countries) > 0 && !countries[country] {
+			continue
+		}
+
+		subdivision, ok := postal.LookupSubdivision(country, fields[colAdminName])
+		if !ok {
+			subdivision, ok = postal.LookupSubdivision(country, fields[colAdminCode])
+		}
+		if !ok {
+			skipped++
+			continue
+		}
+
+		code := postal.NormalizePostalCode(country, fields[colCode])
+		key := [4]string{country, code, fields[colPlace], subdivision.Code}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if err := w.Write([]string{country, code, fields[colPlace], fields[colDistrict], subdivision.Code}); err != nil {
+			return written, skipped, err
+		}
+		written++
+	}
+	if err := scanner.Err(); err != nil {
+		return written, skipped, err
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return written, skipped, err
+	}
+
+	return written, skipped, buffered.Flush()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "postcodeimport:", err)
+	os.Exit(1)
+}
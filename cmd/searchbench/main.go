@@ -0,0 +1,234 @@
+// Command searchbench seeds a large synthetic address book and compares the
+// latency and hit counts of the substring and fuzzy search modes of the
+// address list query. The contacts go into the default book of a dedicated
+// user, and every search runs through the address service with that book
+// as scope, like GET /api/v1/books/:book_id/addresses. It uses the same
+// DB_* environment as the server:
+//
+//	go run ./cmd/searchbench -rows 200000 -iterations 20
+package main
+
+import (
+	"address-book-server/dto"
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/repository"
+	"address-book-server/service"
+	"address-book-server/utils"
+
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const benchEmail = "searchbench@example.invalid"
+
+var (
+	firstNames = []string{"John", "Maria", "José", "Anna", "Rahul", "Priya", "Liam", "Olivia", "Noah", "Emma",
+		"Lucas", "Sofia", "Mateo", "Mia", "Arjun", "Aisha", "Ethan", "Chloe", "Kenji", "Yuki"}
+	lastNames = []string{"Smith", "Müller", "Garcia", "Sharma", "Johnson", "Brown", "Patel", "Rossi", "Nguyen", "Kowalski",
+		"Williams", "Jones", "Fernandes", "Schmidt", "Singh", "Tanaka", "Dubois", "Silva", "Kim", "Andersson"}
+	cities = []string{"Mumbai", "Berlin", "Madrid", "London", "New York", "Paris", "Tokyo", "Lisbon", "Toronto", "Sydney"}
+
+	// Each term is a realistic typo of seeded data; substring search is
+	// expected to miss most of them.
+	terms = []string{"Jonh", "Smtih", "Muller", "Garica", "Rahul Sharam", "Olvia", "Berln", "Tokio", "Kowalsky", "Fernandez"}
+)
+
+func main() {
+	rows := flag.Int("rows", 100000, "number of contacts to seed")
+	iterations := flag.Int("iterations", 10, "runs per search term and mode")
+	reseed := flag.Bool("reseed", false, "delete and re-create the benchmark contacts")
+	flag.Parse()
+
+	logger.InitLogger()
+	_ = godotenv.Load()
+
+	db := utils.Connect()
+	utils.PerformMigration(db)
+
+	// The service logs every list query, which would bury the results.
+	logger.Log = zap.NewNop()
+
+	user, err := benchUser(db)
+	if err != nil {
+		fail(err)
+	}
+
+	bookRepo := repository.NewAddressBookRepository(db)
+
+	book, err := bookRepo.FindOrCreateDefault(user.ID)
+	if err != nil {
+		fail(err)
+	}
+
+	if err := seed(db, user.ID, book.ID, *rows, *reseed); err != nil {
+		fail(err)
+	}
+
+	addresses := service.NewAddressService(
+		repository.NewAddressRepository(db),
+		repository.NewGroupRepository(db),
+		repository.NewCustomFieldRepository(db),
+		repository.NewUserRepository(db),
+		bookRepo,
+		nil,
+	)
+
+	fmt.Printf("%-14s %12s %8s %12s %8s %8s\n", "term", "substring", "hits", "fuzzy", "hits", "speedup")
+
+	var substringTotal, fuzzyTotal time.Duration
+
+	for _, term := range terms {
+		substring, substringHits, err := measure(addresses, user.ID, book.ID, term, utils.SearchModeSubstring, *iterations)
+		if err != nil {
+			fail(err)
+		}
+
+		fuzzy, fuzzyHits, err := measure(addresses, user.ID, book.ID, term, utils.SearchModeFuzzy, *iterations)
+		if err != nil {
+			fail(err)
+		}
+
+		substringTotal += substring
+		fuzzyTotal += fuzzy
+
+		fmt.Printf("%-14s %12s %8d %12s %8d %7.1fx\n", term, substring, substringHits, fuzzy, fuzzyHits,
+			float64(substring)/float64(fuzzy))
+	}
+
+	fmt.Printf("%-14s %12s %8s %12s %8s %7.1fx\n", "sum", substringTotal, "", fuzzyTotal, "",
+		float64(substringTotal)/float64(fuzzyTotal))
+}
+
+// benchUser finds or registers the benchmark account. Its password is
+// random and never printed, so nobody can sign in to it.
+func benchUser(db *gorm.DB) (*model.User, error) {
+	var user model.User
+
+	err := db.Where("email = ?", benchEmail).First(&user).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &user, err
+	}
+
+	password, err := utils.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user = model.User{Email: benchEmail, PasswordHash: string(hash)}
+	return &user, db.Create(&user).Error
+}
+
+func seed(db *gorm.DB, userID, bookID uint64, rows int, reseed bool) error {
+	if reseed {
+		if err := db.Where("user_id = ? AND book_id = ?", userID, bookID).Delete(&model.Address{}).Error; err != nil {
+			return err
+		}
+	}
+
+	var existing int64
+	if err := db.Model(&model.Address{}).Where("user_id = ? AND book_id = ? AND is_deleted = false", userID, bookID).Count(&existing).Error; err != nil {
+		return err
+	}
+
+	if existing >= int64(rows) {
+		fmt.Printf("using %d existing contacts\n", existing)
+		return nil
+	}
+
+	fmt.Printf("seeding %d contacts...\n", int64(rows)-existing)
+
+	random := rand.New(rand.NewSource(42))
+	batch := make([]model.Address, 0, 1000)
+
+	for i := existing; i < int64(rows); i++ {
+		first := firstNames[random.Intn(len(firstNames))]
+		last := lastNames[random.Intn(len(lastNames))]
+		email := fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), i)
+		phone := fmt.Sprintf("+91%010d", random.Int63n(1e10))
+
+		address := model.Address{
+			UserID:       userID,
+			BookID:       bookID,
+			FirstName:    first,
+			LastName:     last,
+			Email:        email,
+			Phone:        phone,
+			AddressLine1: fmt.Sprintf("%d Main Street", random.Intn(999)+1),
+			City:         cities[random.Intn(len(cities))],
+			Emails:       []model.AddressEmail{{Type: "other", Email: email, IsPrimary: true}},
+			Phones:       []model.AddressPhone{{Type: "other", Phone: phone, IsPrimary: true}},
+		}
+		utils.ApplySearchKeys(&address)
+		batch = append(batch, address)
+
+		if len(batch) == cap(batch) {
+			if err := db.Create(&batch).Error; err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := db.Create(&batch).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Exec("ANALYZE addresses, address_emails, address_phones").Error
+}
+
+// measure returns the median latency of a first-page list of the book,
+// including the total count the endpoint computes by default, and the
+// number of hits. Each run gets a fresh query, as the service rewrites it.
+func measure(addresses service.AddressService, userID, bookID uint64, term, mode string, iterations int) (time.Duration, int64, error) {
+	durations := make([]time.Duration, 0, iterations)
+	var hits int64
+
+	for i := 0; i < iterations; i++ {
+		query := dto.ListAddressQuery{
+			BookID:     bookID,
+			Search:     term,
+			SearchMode: mode,
+			Limit:      20,
+		}
+
+		start := time.Now()
+
+		_, meta, err := addresses.ListWithFilters(userID, &query)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		durations = append(durations, time.Since(start))
+		if meta.Total != nil {
+			hits = *meta.Total
+		}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return durations[len(durations)/2].Round(10 * time.Microsecond), hits, nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "searchbench:", err)
+	os.Exit(1)
+}
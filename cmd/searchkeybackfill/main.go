@@ -0,0 +1,121 @@
+// Command searchkeybackfill computes the normalized search keys of contacts
+// written before they existed, or under a different search key version, such
+// as after changing SEARCH_TRANSLITERATE. Until it runs, fuzzy and accent
+// insensitive search miss those contacts. Contacts are processed in id order
+// and in batches, each batch in its own transaction, so it can be stopped and
+// rerun at any time. It uses the same DB_* environment as the server:
+//
+//	go run ./cmd/searchkeybackfill -batch 1000
+package main
+
+import (
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/utils"
+
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func main() {
+	batch := flag.Int("batch", 500, "contacts per transaction")
+	all := flag.Bool("all", false, "recompute keys that are already up to date")
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	flag.Parse()
+
+	logger.InitLogger()
+	_ = godotenv.Load()
+
+	if *batch < 1 {
+		fail(fmt.Errorf("-batch must be positive"))
+	}
+
+	db := utils.Connect()
+	utils.PerformMigration(db)
+
+	processed, err := backfill(db, *batch, *all, *dryRun)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("processed %d contacts under search key version %s", processed, utils.SearchKeyVersion())
+	if *dryRun {
+		fmt.Print(" (dry run, nothing written)")
+	}
+	fmt.Println()
+}
+
+func backfill(db *gorm.DB, batchSize int, all, dryRun bool) (int, error) {
+	version := utils.SearchKeyVersion()
+	var lastID uint64
+	processed := 0
+
+	for {
+		var addresses []model.Address
+
+		query := db.Preload("PostalAddresses").Where("id > ?", lastID)
+		if !all {
+			query = query.Where("search_key_version IS NULL OR search_key_version <> ?", version)
+		}
+
+		if err := query.Order("id").Limit(batchSize).Find(&addresses).Error; err != nil {
+			return processed, err
+		}
+
+		if len(addresses) == 0 {
+			return processed, nil
+		}
+
+		processed += len(addresses)
+		lastID = addresses[len(addresses)-1].ID
+
+		if dryRun {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range addresses {
+				address := &addresses[i]
+				utils.ApplySearchKeys(address)
+
+				if err := tx.Model(&model.Address{}).Where("id = ?", address.ID).UpdateColumns(map[string]interface{}{
+					"name_key":                 address.NameKey,
+					"search_key_version":       address.SearchKeyVersion,
+					"first_name_metaphone":     address.FirstNameMetaphone,
+					"first_name_metaphone_alt": address.FirstNameMetaphoneAlt,
+					"first_name_soundex":       address.FirstNameSoundex,
+					"last_name_metaphone":      address.LastNameMetaphone,
+					"last_name_metaphone_alt":  address.LastNameMetaphoneAlt,
+					"last_name_soundex":        address.LastNameSoundex,
+				}).Error; err != nil {
+					return err
+				}
+
+				for _, p := range address.PostalAddresses {
+					if err := tx.Model(&model.PostalAddress{}).Where("id = ?", p.ID).UpdateColumns(map[string]interface{}{
+						"city_key":    p.CityKey,
+						"country_key": p.CountryKey,
+					}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return processed, err
+		}
+
+		logger.Log.Info("Backfilled search keys", zap.Uint64("last_id", lastID), zap.Int("count", len(addresses)))
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "searchkeybackfill:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddressBookController interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type addressBookController struct {
+	bookService service.AddressBookService
+}
+
+func NewAddressBookController(bookService service.AddressBookService) AddressBookController {
+	return &addressBookController{bookService: bookService}
+}
+
+func (c *addressBookController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	books, err := c.bookService.List(ownerId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"books": books,
+		},
+	})
+}
+
+func (c *addressBookController) Create(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	req, ok := bindAddressBookRequest(ctx)
+	if !ok {
+		return
+	}
+
+	book, err := c.bookService.Create(ownerId, req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"book": book,
+		},
+	})
+}
+
+func (c *addressBookController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	req, ok := bindAddressBookRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.bookService.Update(ctx.GetUint64("book_id"), ownerId, req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Address book updated",
+		},
+	})
+}
+
+func (c *addressBookController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	if err := c.bookService.Delete(ctx.GetUint64("book_id"), ownerId); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Address book deleted",
+		},
+	})
+}
+
+func bindAddressBookRequest(ctx *gin.Context) (*dto.AddressBookRequest, bool) {
+	var req dto.AddressBookRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return nil, false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return nil, false
+	}
+
+	return &req, true
+}
@@ -4,13 +4,15 @@ import (
 	"address-book-server/dto"
 	appError "address-book-server/error"
 	"address-book-server/logger"
-	"address-book-server/model"
+	"address-book-server/mapper"
 	"address-book-server/service"
 	"address-book-server/utils"
 	"address-book-server/validator"
 	
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -18,11 +20,16 @@ import (
 
 type AddressController interface {
 	List(ctx *gin.Context)
+	Get(ctx *gin.Context)
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 	Export(ctx *gin.Context)
-	runExportJob(userId uint64, req dto.ExportAddressRequest)
+	Upcoming(ctx *gin.Context)
+	Move(ctx *gin.Context)
+	Copy(ctx *gin.Context)
+	Regeocode(ctx *gin.Context)
+	runExportJob(ownerId uint64, req dto.ExportAddressRequest)
 }
 
 type addressController struct {
@@ -35,7 +42,7 @@ func NewAddressController(addressService service.AddressService) AddressControll
 
 func (c *addressController) List(ctx *gin.Context) {
 
-	userId := ctx.GetUint64("user_id")
+	ownerId := ctx.GetUint64("owner_id")
 
 	var query dto.ListAddressQuery
 
@@ -50,39 +57,163 @@ func (c *addressController) List(ctx *gin.Context) {
 		return
 	}
 
-	response, total, err := c.addressService.ListWithFilters(userId, query)
+	query.CustomFields = ctx.QueryMap("cf")
+	query.BookID = ctx.GetUint64("book_id")
+
+	if err := validator.Validate.Struct(query); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	response, page, err := c.addressService.ListWithFilters(ownerId, &query)
 
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	page := query.Page
 	limit := query.Limit
 
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
+	if limit <= 0 || limit > 100 {
 		limit = 10
 	}
 
+	meta := gin.H{
+		"limit": limit,
+		"owner_id": ownerId,
+		"book_id": query.BookID,
+		"role": ctx.GetString("role"),
+		"sort": query.Sort,
+		"collation": utils.SortCollation(),
+	}
+
+	if query.After == "" && query.Before == "" {
+		pageNumber := query.Page
+		if pageNumber <= 0 {
+			pageNumber = 1
+		}
+		meta["page"] = pageNumber
+	}
+
+	if query.SearchMode == utils.SearchModeFuzzy {
+		meta["threshold"] = query.Threshold
+	}
+
+	if query.Phonetic {
+		meta["phonetic"] = true
+	}
+
+	if page.Total != nil {
+		meta["total"] = *page.Total
+		meta["total_pages"] = (*page.Total + int64(limit) - 1) / int64(limit)
+	}
+
+	if page.NextCursor != "" {
+		meta["next_cursor"] = page.NextCursor
+	}
+	if page.PrevCursor != "" {
+		meta["prev_cursor"] = page.PrevCursor
+	}
+
+	if links := paginationLinks(ctx, page); links != "" {
+		ctx.Header("Link", links)
+	}
+
+	var addresses interface{} = response
+	if len(query.SelectFields) > 0 {
+		sparse := make([]map[string]interface{}, 0, len(response))
+		for _, r := range response {
+			sparse = append(sparse, mapper.ToSparseAddressResponse(r, query.SelectFields))
+		}
+		addresses = sparse
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data": gin.H{
-			"addresses": response,
+			"addresses": addresses,
 		},
-		"meta": gin.H{
-			"page": page,
-			"limit": limit,
-			"total": total,
-			"total_pages": (total + int64(limit) - 1) / int64(limit), 
+		"meta": meta,
+	})
+}
+
+func (c *addressController) Get(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid address ID",
+				err,
+			),
+		)
+		return
+	}
+
+	var query dto.GetAddressQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request body",
+				err,
+			),
+		)
+		return
+	}
+
+	response, err := c.addressService.Get(id, ownerId, ctx.GetUint64("book_id"), &query)
+
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var address interface{} = response
+	if len(query.SelectFields) > 0 {
+		address = mapper.ToSparseAddressResponse(*response, query.SelectFields)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"address": address,
 		},
 	})
 }
 
+// paginationLinks builds an RFC 8288 Link header pointing at the next and
+// previous pages, keeping every other query parameter of the request.
+func paginationLinks(ctx *gin.Context, page *dto.ListAddressMeta) string {
+	links := []string{}
+
+	link := func(param, cursor, rel string) {
+		values := ctx.Request.URL.Query()
+		values.Del("page")
+		values.Del("after")
+		values.Del("before")
+		values.Set(param, cursor)
+
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="%s"`, ctx.Request.URL.Path, values.Encode(), rel))
+	}
+
+	if page.NextCursor != "" {
+		link("after", page.NextCursor, "next")
+	}
+	if page.PrevCursor != "" {
+		link("before", page.PrevCursor, "prev")
+	}
+
+	return strings.Join(links, ", ")
+}
+
 func (c *addressController) Create(ctx *gin.Context) {
-	userId := ctx.GetUint64("user_id")
+	ownerId := ctx.GetUint64("owner_id")
 
 	var req dto.CreateAddressRequest
 
@@ -105,20 +236,7 @@ func (c *addressController) Create(ctx *gin.Context) {
 		return
 	}
 
-	address := model.Address{
-		FirstName: req.FirstName,
-		LastName: req.LastName,
-		Email: req.Email,
-		Phone: req.Phone,
-		AddressLine1: req.AddressLine1,
-		AddressLine2: req.AddressLine2,
-		City: req.City,
-		State: req.State,
-		Country: req.Country,
-		Pincode: req.Pincode,
-	}
-
-	if err := c.addressService.Create(userId, &address); err != nil {
+	if err := c.addressService.Create(ownerId, ctx.GetUint64("book_id"), &req); err != nil {
 		ctx.Error(err)
 		return
 	}
@@ -132,7 +250,7 @@ func (c *addressController) Create(ctx *gin.Context) {
 }
 
 func (c *addressController) Update(ctx *gin.Context) {
-	userId := ctx.GetUint64("user_id")
+	ownerId := ctx.GetUint64("owner_id")
 	
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	
@@ -158,7 +276,16 @@ func (c *addressController) Update(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.addressService.Update(id, userId, &req); err != nil {
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	if err := c.addressService.Update(id, ownerId, ctx.GetUint64("book_id"), &req); err != nil {
 		ctx.Error(err)
 		return
 	}
@@ -172,7 +299,7 @@ func (c *addressController) Update(ctx *gin.Context) {
 }
 
 func (c *addressController) Delete(ctx *gin.Context) {
-	userId := ctx.GetUint64("user_id")
+	ownerId := ctx.GetUint64("owner_id")
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 
 	if err != nil {
@@ -185,7 +312,7 @@ func (c *addressController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.addressService.Delete(id, userId); err != nil {
+	if err := c.addressService.Delete(id, ownerId, ctx.GetUint64("book_id")); err != nil {
 		ctx.Error(err)
 		return
 	}
@@ -199,7 +326,7 @@ func (c *addressController) Delete(ctx *gin.Context) {
 }
 
 func (c *addressController) Export(ctx *gin.Context) {
-	userId := ctx.GetUint64("user_id")
+	ownerId := ctx.GetUint64("owner_id")
 
 	var req dto.ExportAddressRequest
 
@@ -222,9 +349,11 @@ func (c *addressController) Export(ctx *gin.Context) {
 		return
 	}
 
-	go func(userId uint64, req dto.ExportAddressRequest) {
-		c.runExportJob(userId, req)
-	}(userId, req)
+	req.BookID = ctx.GetUint64("book_id")
+
+	go func(ownerId uint64, req dto.ExportAddressRequest) {
+		c.runExportJob(ownerId, req)
+	}(ownerId, req)
 	
 	ctx.JSON(http.StatusAccepted, gin.H{
 		"status": "success",
@@ -235,13 +364,146 @@ func (c *addressController) Export(ctx *gin.Context) {
 
 }
 
-func (c *addressController) runExportJob(userId uint64, req dto.ExportAddressRequest) {
-	csvData, err := c.addressService.ExportCSV(userId, req.Fields)
+func (c *addressController) Upcoming(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	days := 30
+
+	if raw := ctx.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+
+		if err != nil || parsed < 0 || parsed > 366 {
+			ctx.Error(
+				appError.BadRequest(
+					"days must be a number between 0 and 366",
+					err,
+				),
+			)
+			return
+		}
+
+		days = parsed
+	}
+
+	events, err := c.addressService.Upcoming(ownerId, ctx.GetUint64("book_id"), days)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"events": events,
+		},
+		"meta": gin.H{
+			"days": days,
+		},
+	})
+}
+
+func (c *addressController) Move(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	req, ok := bindTransferRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.addressService.Move(ownerId, ctx.GetUint64("book_id"), req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Contacts moved",
+		},
+	})
+}
+
+func (c *addressController) Copy(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	req, ok := bindTransferRequest(ctx)
+	if !ok {
+		return
+	}
+
+	ids, err := c.addressService.Copy(ownerId, ctx.GetUint64("book_id"), req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"address_ids": ids,
+		},
+	})
+}
+
+func bindTransferRequest(ctx *gin.Context) (*dto.TransferAddressesRequest, bool) {
+	var req dto.TransferAddressesRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return nil, false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return nil, false
+	}
+
+	return &req, true
+}
+
+func (c *addressController) Regeocode(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid address ID",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := c.addressService.Regeocode(id, ownerId, ctx.GetUint64("book_id")); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Geocoding queued",
+		},
+	})
+}
+
+func (c *addressController) runExportJob(ownerId uint64, req dto.ExportAddressRequest) {
+	csvData, err := c.addressService.ExportCSV(ownerId, req)
 	
 	if err != nil {
 		logger.Log.Error(
 			"Export failed",
-			zap.Uint64("user_id", userId),
+			zap.Uint64("owner_id", ownerId),
 			zap.String("error", err.Error()),
 		)
 		return
@@ -258,7 +520,7 @@ func (c *addressController) runExportJob(userId uint64, req dto.ExportAddressReq
 	if err != nil {
 		logger.Log.Error(
 			"Email sending failed",
-			zap.Uint64("user_id", userId),
+			zap.Uint64("owner_id", ownerId),
 			zap.String("error", err.Error()),
 		)
 		return
@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxAttachmentSize = 10 << 20
+
+type AttachmentController interface {
+	List(ctx *gin.Context)
+	Upload(ctx *gin.Context)
+	Download(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type attachmentController struct {
+	attachmentService service.AttachmentService
+}
+
+func NewAttachmentController(attachmentService service.AttachmentService) AttachmentController {
+	return &attachmentController{attachmentService: attachmentService}
+}
+
+func (c *attachmentController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	attachments, err := c.attachmentService.List(addressId, ownerId, ctx.GetUint64("book_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"attachments": attachments,
+		},
+	})
+}
+
+func (c *attachmentController) Upload(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxAttachmentSize+(1<<20))
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"A file is required",
+				err,
+			),
+		)
+		return
+	}
+
+	if fileHeader.Size > maxAttachmentSize {
+		ctx.Error(
+			appError.BadRequest(
+				"Attachment must be at most 10 MB",
+				nil,
+			),
+		)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid file",
+				err,
+			),
+		)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxAttachmentSize))
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid file",
+				err,
+			),
+		)
+		return
+	}
+
+	attachment, err := c.attachmentService.Upload(addressId, ownerId, ctx.GetUint64("book_id"), fileHeader.Filename, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"attachment": attachment,
+		},
+	})
+}
+
+func (c *attachmentController) Download(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := parseAttachmentID(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, data, err := c.attachmentService.Download(id, addressId, ownerId, ctx.GetUint64("book_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.FileName,
+	}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Data(http.StatusOK, attachment.ContentType, data)
+}
+
+func (c *attachmentController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := parseAttachmentID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAttachmentRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	attachment, err := c.attachmentService.Rename(id, addressId, ownerId, ctx.GetUint64("book_id"), &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"attachment": attachment,
+		},
+	})
+}
+
+func (c *attachmentController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := parseAttachmentID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.attachmentService.Delete(id, addressId, ownerId, ctx.GetUint64("book_id")); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Attachment deleted",
+		},
+	})
+}
+
+func parseAttachmentID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("attachmentId"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid attachment ID",
+				err,
+			),
+		)
+		return 0, false
+	}
+
+	return id, true
+}
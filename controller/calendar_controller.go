@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"address-book-server/service"
+
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController interface {
+	RegenerateToken(ctx *gin.Context)
+	RevokeToken(ctx *gin.Context)
+	Feed(ctx *gin.Context)
+}
+
+type calendarController struct {
+	calendarService service.CalendarService
+}
+
+func NewCalendarController(calendarService service.CalendarService) CalendarController {
+	return &calendarController{calendarService: calendarService}
+}
+
+func (c *calendarController) RegenerateToken(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	token, err := c.calendarService.RegenerateToken(userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"feed_url": "/api/v1/calendar/feed/" + token + ".ics",
+		},
+	})
+}
+
+func (c *calendarController) RevokeToken(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	if err := c.calendarService.RevokeToken(userId); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Calendar feed disabled",
+		},
+	})
+}
+
+func (c *calendarController) Feed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	feed, err := c.calendarService.Feed(token)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=3600")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
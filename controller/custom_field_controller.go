@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldController interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type customFieldController struct {
+	customFieldService service.CustomFieldService
+}
+
+func NewCustomFieldController(customFieldService service.CustomFieldService) CustomFieldController {
+	return &customFieldController{customFieldService: customFieldService}
+}
+
+func (c *customFieldController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	fields, err := c.customFieldService.List(ownerId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"custom_fields": fields,
+		},
+	})
+}
+
+func (c *customFieldController) Create(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	var req dto.CreateCustomFieldRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	field, err := c.customFieldService.Create(ownerId, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"custom_field": field,
+		},
+	})
+}
+
+func (c *customFieldController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid custom field ID",
+				err,
+			),
+		)
+		return
+	}
+
+	var req dto.UpdateCustomFieldRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	if err := c.customFieldService.Update(id, ownerId, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Custom field updated",
+		},
+	})
+}
+
+func (c *customFieldController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid custom field ID",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := c.customFieldService.Delete(id, ownerId); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Custom field deleted",
+		},
+	})
+}
@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GroupController interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	AddMembers(ctx *gin.Context)
+	RemoveMembers(ctx *gin.Context)
+}
+
+type groupController struct {
+	groupService service.GroupService
+}
+
+func NewGroupController(groupService service.GroupService) GroupController {
+	return &groupController{groupService: groupService}
+}
+
+func (c *groupController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	groups, err := c.groupService.List(ownerId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"groups": groups,
+		},
+	})
+}
+
+func (c *groupController) Create(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	var req dto.CreateGroupRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	group, err := c.groupService.Create(ownerId, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"group": group,
+		},
+	})
+}
+
+func (c *groupController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseGroupID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateGroupRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	if err := c.groupService.Update(id, ownerId, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Group updated",
+		},
+	})
+}
+
+func (c *groupController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseGroupID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.Delete(id, ownerId); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Group deleted",
+		},
+	})
+}
+
+func (c *groupController) AddMembers(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseGroupID(ctx)
+	if !ok {
+		return
+	}
+
+	req, ok := bindGroupMembersRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.AddMembers(id, ownerId, req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Contacts added to the group",
+		},
+	})
+}
+
+func (c *groupController) RemoveMembers(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseGroupID(ctx)
+	if !ok {
+		return
+	}
+
+	req, ok := bindGroupMembersRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.RemoveMembers(id, ownerId, req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Contacts removed from the group",
+		},
+	})
+}
+
+func parseGroupID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid group ID",
+				err,
+			),
+		)
+		return 0, false
+	}
+
+	return id, true
+}
+
+func bindGroupMembersRequest(ctx *gin.Context) (*dto.GroupMembersRequest, bool) {
+	var req dto.GroupMembersRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return nil, false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return nil, false
+	}
+
+	return &req, true
+}
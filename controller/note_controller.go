@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NoteController interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type noteController struct {
+	noteService service.NoteService
+}
+
+func NewNoteController(noteService service.NoteService) NoteController {
+	return &noteController{noteService: noteService}
+}
+
+func (c *noteController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	notes, err := c.noteService.List(addressId, ownerId, ctx.GetUint64("book_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"notes": notes,
+		},
+	})
+}
+
+func (c *noteController) Create(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	req, ok := bindNoteRequest(ctx)
+	if !ok {
+		return
+	}
+
+	note, err := c.noteService.Create(addressId, ownerId, ctx.GetUint64("book_id"), req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"note": note,
+		},
+	})
+}
+
+func (c *noteController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := parseNoteID(ctx)
+	if !ok {
+		return
+	}
+
+	req, ok := bindNoteRequest(ctx)
+	if !ok {
+		return
+	}
+
+	note, err := c.noteService.Update(id, addressId, ownerId, ctx.GetUint64("book_id"), req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"note": note,
+		},
+	})
+}
+
+func (c *noteController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	addressId, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := parseNoteID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.noteService.Delete(id, addressId, ownerId, ctx.GetUint64("book_id")); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Note deleted",
+		},
+	})
+}
+
+func parseNoteID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("noteId"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid note ID",
+				err,
+			),
+		)
+		return 0, false
+	}
+
+	return id, true
+}
+
+func bindNoteRequest(ctx *gin.Context) (*dto.NoteRequest, bool) {
+	var req dto.NoteRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return nil, false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return nil, false
+	}
+
+	return &req, true
+}
@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationController interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Members(ctx *gin.Context)
+	UpdateMember(ctx *gin.Context)
+	RemoveMember(ctx *gin.Context)
+	Invites(ctx *gin.Context)
+	Invite(ctx *gin.Context)
+	RevokeInvite(ctx *gin.Context)
+	ReceivedInvites(ctx *gin.Context)
+	AcceptInvite(ctx *gin.Context)
+}
+
+type organizationController struct {
+	organizationService service.OrganizationService
+}
+
+func NewOrganizationController(organizationService service.OrganizationService) OrganizationController {
+	return &organizationController{organizationService: organizationService}
+}
+
+func (c *organizationController) List(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	organizations, err := c.organizationService.List(userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"organizations": organizations,
+		},
+	})
+}
+
+func (c *organizationController) Create(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	var req dto.CreateOrganizationRequest
+	if !bindOrganizationRequest(ctx, &req) {
+		return
+	}
+
+	organization, err := c.organizationService.Create(userId, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"organization": organization,
+		},
+	})
+}
+
+func (c *organizationController) Members(ctx *gin.Context) {
+	members, err := c.organizationService.Members(ctx.GetUint64("org_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"members": members,
+		},
+	})
+}
+
+func (c *organizationController) UpdateMember(ctx *gin.Context) {
+	id, ok := parseOrganizationParam(ctx, "memberId", "Invalid member ID")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMemberRequest
+	if !bindOrganizationRequest(ctx, &req) {
+		return
+	}
+
+	err := c.organizationService.UpdateMember(ctx.GetUint64("org_id"), id, ctx.GetString("org_role"), &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Member updated",
+		},
+	})
+}
+
+func (c *organizationController) RemoveMember(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	id, ok := parseOrganizationParam(ctx, "memberId", "Invalid member ID")
+	if !ok {
+		return
+	}
+
+	err := c.organizationService.RemoveMember(ctx.GetUint64("org_id"), id, userId, ctx.GetString("org_role"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Member removed",
+		},
+	})
+}
+
+func (c *organizationController) Invites(ctx *gin.Context) {
+	invites, err := c.organizationService.Invites(ctx.GetUint64("org_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"invites": invites,
+		},
+	})
+}
+
+func (c *organizationController) Invite(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	var req dto.OrganizationInviteRequest
+	if !bindOrganizationRequest(ctx, &req) {
+		return
+	}
+
+	invite, err := c.organizationService.Invite(ctx.GetUint64("org_id"), userId, ctx.GetString("org_role"), &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"invite": invite,
+		},
+	})
+}
+
+func (c *organizationController) RevokeInvite(ctx *gin.Context) {
+	id, ok := parseOrganizationParam(ctx, "inviteId", "Invalid invitation ID")
+	if !ok {
+		return
+	}
+
+	if err := c.organizationService.RevokeInvite(ctx.GetUint64("org_id"), id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Invitation revoked",
+		},
+	})
+}
+
+func (c *organizationController) ReceivedInvites(ctx *gin.Context) {
+	invites, err := c.organizationService.ReceivedInvites(ctx.GetString("email"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"invites": invites,
+		},
+	})
+}
+
+func (c *organizationController) AcceptInvite(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	var req dto.AcceptOrganizationInviteRequest
+	if !bindOrganizationRequest(ctx, &req) {
+		return
+	}
+
+	organization, err := c.organizationService.AcceptInvite(userId, ctx.GetString("email"), req.Token)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"organization": organization,
+		},
+	})
+}
+
+func bindOrganizationRequest(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return false
+	}
+
+	return true
+}
+
+func parseOrganizationParam(ctx *gin.Context, name, message string) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				message,
+				err,
+			),
+		)
+		return 0, false
+	}
+
+	return id, true
+}
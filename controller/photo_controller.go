@@ -0,0 +1,155 @@
+package controller
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/mapper"
+	"address-book-server/service"
+
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxPhotoSize = 5 << 20
+
+type PhotoController interface {
+	Upload(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type photoController struct {
+	photoService service.PhotoService
+}
+
+func NewPhotoController(photoService service.PhotoService) PhotoController {
+	return &photoController{photoService: photoService}
+}
+
+func (c *photoController) Upload(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPhotoSize+(1<<20))
+
+	fileHeader, err := ctx.FormFile("photo")
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"A photo file is required",
+				err,
+			),
+		)
+		return
+	}
+
+	if fileHeader.Size > maxPhotoSize {
+		ctx.Error(
+			appError.BadRequest(
+				"Photo must be at most 5 MB",
+				nil,
+			),
+		)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid photo file",
+				err,
+			),
+		)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxPhotoSize))
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid photo file",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := c.photoService.Upload(id, ownerId, ctx.GetUint64("book_id"), data); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	photoURL := mapper.PhotoURL(ctx.GetUint64("book_id"), id)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message":       "Photo uploaded",
+			"photo_url":     photoURL,
+			"thumbnail_url": photoURL + "?size=thumbnail",
+		},
+	})
+}
+
+func (c *photoController) Get(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	data, contentType, err := c.photoService.Get(id, ownerId, ctx.GetUint64("book_id"), ctx.Query("size") == "thumbnail")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func (c *photoController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseAddressID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.photoService.Delete(id, ownerId, ctx.GetUint64("book_id")); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Photo deleted",
+		},
+	})
+}
+
+func parseAddressID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid address ID",
+				err,
+			),
+		)
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"address-book-server/service"
+
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReferenceController interface {
+	PostalCode(ctx *gin.Context)
+	Geocoder(ctx *gin.Context)
+}
+
+type referenceController struct {
+	referenceService service.ReferenceService
+}
+
+func NewReferenceController(referenceService service.ReferenceService) ReferenceController {
+	return &referenceController{referenceService: referenceService}
+}
+
+func (c *referenceController) PostalCode(ctx *gin.Context) {
+	response, err := c.referenceService.LookupPostalCode(ctx.Param("country"), ctx.Param("code"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"postal_code": response,
+		},
+	})
+}
+
+func (c *referenceController) Geocoder(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"geocoder": c.referenceService.Geocoder(),
+		},
+	})
+}
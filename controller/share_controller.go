@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareController interface {
+	List(ctx *gin.Context)
+	Invite(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Revoke(ctx *gin.Context)
+	Received(ctx *gin.Context)
+	Accept(ctx *gin.Context)
+}
+
+type shareController struct {
+	shareService service.ShareService
+}
+
+func NewShareController(shareService service.ShareService) ShareController {
+	return &shareController{shareService: shareService}
+}
+
+func (c *shareController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	shares, err := c.shareService.List(ownerId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"shares": shares,
+		},
+	})
+}
+
+func (c *shareController) Invite(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+	userId := ctx.GetUint64("user_id")
+
+	var req dto.CreateShareRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	share, err := c.shareService.Invite(ownerId, userId, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"share": share,
+		},
+	})
+}
+
+func (c *shareController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseShareID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateShareRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	if err := c.shareService.UpdateRole(id, ownerId, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Share updated",
+		},
+	})
+}
+
+func (c *shareController) Revoke(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, ok := parseShareID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.shareService.Revoke(id, ownerId); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Share revoked",
+		},
+	})
+}
+
+func (c *shareController) Received(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	shares, err := c.shareService.Received(userId, ctx.GetString("email"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"shares": shares,
+		},
+	})
+}
+
+func (c *shareController) Accept(ctx *gin.Context) {
+	userId := ctx.GetUint64("user_id")
+
+	var req dto.AcceptShareRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	share, err := c.shareService.Accept(userId, ctx.GetString("email"), req.Token)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"share": share,
+		},
+	})
+}
+
+func parseShareID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid share ID",
+				err,
+			),
+		)
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/service"
+	"address-book-server/utils"
+	"address-book-server/validator"
+
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagController interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Assign(ctx *gin.Context)
+	Unassign(ctx *gin.Context)
+}
+
+type tagController struct {
+	tagService service.TagService
+}
+
+func NewTagController(tagService service.TagService) TagController {
+	return &tagController{tagService: tagService}
+}
+
+func (c *tagController) List(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	tags, err := c.tagService.List(ownerId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"tags": tags,
+		},
+	})
+}
+
+func (c *tagController) Create(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	var req dto.CreateTagRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	tag, err := c.tagService.Create(ownerId, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"tag": tag,
+		},
+	})
+}
+
+func (c *tagController) Update(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid tag ID",
+				err,
+			),
+		)
+		return
+	}
+
+	var req dto.UpdateTagRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return
+	}
+
+	if err := c.tagService.Update(id, ownerId, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Tag updated",
+		},
+	})
+}
+
+func (c *tagController) Delete(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid tag ID",
+				err,
+			),
+		)
+		return
+	}
+
+	if err := c.tagService.Delete(id, ownerId); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Tag deleted",
+		},
+	})
+}
+
+func (c *tagController) Assign(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	req, ok := bindBulkTagRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.tagService.Assign(ownerId, req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Tags assigned",
+		},
+	})
+}
+
+func (c *tagController) Unassign(ctx *gin.Context) {
+	ownerId := ctx.GetUint64("owner_id")
+
+	req, ok := bindBulkTagRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.tagService.Unassign(ownerId, req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Tags removed",
+		},
+	})
+}
+
+func bindBulkTagRequest(ctx *gin.Context) (*dto.BulkTagRequest, bool) {
+	var req dto.BulkTagRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(
+			appError.BadRequest(
+				"Invalid request Body",
+				err,
+			),
+		)
+		return nil, false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		ctx.Error(
+			appError.NewValidationError(
+				utils.FormatValidationErrors(err),
+			),
+		)
+		return nil, false
+	}
+
+	return &req, true
+}
@@ -0,0 +1,10 @@
+package dto
+
+type AddressBookRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type TransferAddressesRequest struct {
+	AddressIDs   []uint64 `json:"address_ids" validate:"required,min=1"`
+	TargetBookID uint64   `json:"target_book_id" validate:"required"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type AddressBookResponse struct {
+	Id        uint64 `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+}
@@ -3,30 +3,53 @@ package dto
 type CreateAddressRequest struct {
 	FirstName    string `json:"first_name" validate:"required"`
 	LastName     string `json:"last_name"`
-	Email        string `json:"email" validate:"required,email"`
-	Phone        string `json:"phone"`
-	AddressLine1 string `json:"address_line1" validate:"required"`
+	Email        string `json:"email" validate:"required_without=Emails,omitempty,email"`
+	Phone        string `json:"phone" validate:"omitempty,phone,max=20"`
+	AddressLine1 string `json:"address_line1" validate:"required_without=PostalAddresses"`
 	AddressLine2 string `json:"address_line2"`
 	City         string `json:"city"`
 	State        string `json:"state"`
 	Country      string `json:"country"`
 	Pincode      string `json:"pincode"`
+
+	Emails          []EmailRequest         `json:"emails" validate:"omitempty,dive"`
+	Phones          []PhoneRequest         `json:"phones" validate:"omitempty,dive"`
+	PostalAddresses []PostalAddressRequest `json:"postal_addresses" validate:"omitempty,dive"`
+
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	Events []EventRequest `json:"events" validate:"omitempty,dive"`
 }
 
 type UpdateAddressRequest struct {
 	FirstName    *string `json:"first_name"`
 	LastName     *string `json:"last_name"`
 	Email        *string `json:"email"`
-	Phone        *string `json:"phone"`
+	Phone        *string `json:"phone" validate:"omitempty,phone,max=20"`
 	AddressLine1 *string `json:"address_line1"`
 	AddressLine2 *string `json:"address_line2"`
 	City         *string `json:"city"`
 	State        *string `json:"state"`
 	Country      *string `json:"country"`
 	Pincode      *string `json:"pincode"`
+
+	// A provided collection replaces the stored one entirely.
+	Emails          *[]EmailRequest         `json:"emails" validate:"omitempty,dive"`
+	Phones          *[]PhoneRequest         `json:"phones" validate:"omitempty,dive"`
+	PostalAddresses *[]PostalAddressRequest `json:"postal_addresses" validate:"omitempty,dive"`
+
+	// Keys are merged into the stored values; a null value clears the field.
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	Events *[]EventRequest `json:"events" validate:"omitempty,dive"`
 }
 
 type ExportAddressRequest struct {
 	Fields []string `json:"fields" validate:"required,min=1"`
 	Email  string   `json:"email" validate:"required,email"`
+
+	GroupID          *uint64 `json:"group_id"`
+	IncludeSubgroups bool    `json:"include_subgroups"`
+
+	BookID uint64 `json:"-"`
 }
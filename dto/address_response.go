@@ -1,16 +1,59 @@
 package dto
 
+import "time"
+
 type ListAddressResponse struct {
-	Id           uint64 `json:"id"`
-	UserId       uint64 `json:"user_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	Email        string `json:"email"`
-	Phone        string `json:"phone"`
-	AddressLine1 string `json:"address_line1"`
-	AddressLine2 string `json:"address_line2"`
-	City         string `json:"city"`
-	State        string `json:"state"`
-	Country      string `json:"country"`
-	Pincode      string `json:"pincode"`
+	Id           uint64         `json:"id"`
+	UserId       uint64         `json:"user_id"`
+	BookId       uint64         `json:"book_id"`
+	Owner        *OwnerResponse `json:"owner,omitempty"`
+	FirstName    string         `json:"first_name"`
+	LastName     string         `json:"last_name"`
+	Email        string         `json:"email"`
+	Phone        string         `json:"phone"`
+	PhoneE164    string         `json:"phone_e164"`
+	AddressLine1 string         `json:"address_line1"`
+	AddressLine2 string         `json:"address_line2"`
+	City         string         `json:"city"`
+	State        string         `json:"state"`
+	Country      string         `json:"country"`
+	Pincode      string         `json:"pincode"`
+	CountryCode  string         `json:"country_code"`
+	StateCode    string         `json:"state_code"`
+	PostalCode   string         `json:"postal_code"`
+	Tags         []string       `json:"tags"`
+	PhotoURL     string         `json:"photo_url,omitempty"`
+	ThumbnailURL string         `json:"thumbnail_url,omitempty"`
+
+	Emails          []EmailResponse         `json:"emails"`
+	Phones          []PhoneResponse         `json:"phones"`
+	PostalAddresses []PostalAddressResponse `json:"postal_addresses"`
+
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	Events []EventResponse `json:"events"`
+
+	Location LocationResponse `json:"location"`
+
+	Search *SearchHitResponse `json:"search,omitempty"`
+
+	// DistanceKm is set when listing near a point.
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+}
+
+// LocationResponse holds the geocoded position of a contact. Coordinates
+// are null until geocoding succeeded.
+type LocationResponse struct {
+	Latitude   *float64   `json:"latitude"`
+	Longitude  *float64   `json:"longitude"`
+	Status     string     `json:"status"`
+	Accuracy   string     `json:"accuracy,omitempty"`
+	GeocodedAt *time.Time `json:"geocoded_at,omitempty"`
+}
+
+// SearchHitResponse describes why a contact matched a ranked search.
+type SearchHitResponse struct {
+	Mode      string  `json:"mode"`
+	Score     float64 `json:"score"`
+	Highlight string  `json:"highlight,omitempty"`
 }
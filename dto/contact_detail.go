@@ -0,0 +1,57 @@
+package dto
+
+type EmailRequest struct {
+	Type      string `json:"type" validate:"omitempty,oneof=home work other"`
+	Label     string `json:"label" validate:"omitempty,max=50"`
+	Email     string `json:"email" validate:"required,email"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type PhoneRequest struct {
+	Type      string `json:"type" validate:"omitempty,oneof=home work mobile other"`
+	Label     string `json:"label" validate:"omitempty,max=50"`
+	Phone     string `json:"phone" validate:"required,phone,max=20"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type PostalAddressRequest struct {
+	Type         string `json:"type" validate:"omitempty,oneof=home work other"`
+	Label        string `json:"label" validate:"omitempty,max=50"`
+	AddressLine1 string `json:"address_line1" validate:"required"`
+	AddressLine2 string `json:"address_line2"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	Country      string `json:"country"`
+	Pincode      string `json:"pincode"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+type EmailResponse struct {
+	Type      string `json:"type"`
+	Label     string `json:"label"`
+	Email     string `json:"email"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type PhoneResponse struct {
+	Type      string `json:"type"`
+	Label     string `json:"label"`
+	Phone     string `json:"phone"`
+	E164      string `json:"e164"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type PostalAddressResponse struct {
+	Type         string `json:"type"`
+	Label        string `json:"label"`
+	AddressLine1 string `json:"address_line1"`
+	AddressLine2 string `json:"address_line2"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	Country      string `json:"country"`
+	Pincode      string `json:"pincode"`
+	CountryCode  string `json:"country_code"`
+	StateCode    string `json:"state_code"`
+	PostalCode   string `json:"postal_code"`
+	IsPrimary    bool   `json:"is_primary"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type CreateCustomFieldRequest struct {
+	Key      string   `json:"key" validate:"required,max=50"`
+	Name     string   `json:"name" validate:"required,max=100"`
+	Type     string   `json:"type" validate:"required,oneof=string number date boolean enum"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options" validate:"required_if=Type enum,omitempty,dive,required"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Name     *string   `json:"name" validate:"omitempty,min=1,max=100"`
+	Required *bool     `json:"required"`
+	Options  *[]string `json:"options" validate:"omitempty,dive,required"`
+}
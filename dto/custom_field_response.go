@@ -0,0 +1,10 @@
+package dto
+
+type CustomFieldResponse struct {
+	Id       uint64   `json:"id"`
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options"`
+}
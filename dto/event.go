@@ -0,0 +1,36 @@
+package dto
+
+type EventRequest struct {
+	Type  string `json:"type" validate:"required,oneof=birthday anniversary custom"`
+	Label string `json:"label" validate:"omitempty,max=100"`
+	// Date is YYYY-MM-DD, or --MM-DD when the year is unknown.
+	Date string `json:"date" validate:"required"`
+}
+
+type EventResponse struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	Date  string `json:"date"`
+}
+
+type UpcomingEventResponse struct {
+	AddressId uint64 `json:"address_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Type      string `json:"type"`
+	Label     string `json:"label"`
+	Date      string `json:"date"`
+	NextDate  string `json:"next_date"`
+	DaysUntil int    `json:"days_until"`
+	// Years is the age or anniversary count reached on NextDate, when the
+	// original year is known.
+	Years *int `json:"years,omitempty"`
+}
+
+// MonthDayRange is an inclusive range of MMDD values. From may be greater
+// than To when the range wraps around the end of the year.
+type MonthDayRange struct {
+	From int
+	To   int
+	All  bool
+}
@@ -0,0 +1,18 @@
+package dto
+
+type CreateGroupRequest struct {
+	Name     string  `json:"name" validate:"required,max=100"`
+	ParentID *uint64 `json:"parent_id"`
+}
+
+type UpdateGroupRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=100"`
+	ParentID *uint64 `json:"parent_id"`
+	// MoveToRoot detaches the group from its parent; a null parent_id is
+	// indistinguishable from an omitted one.
+	MoveToRoot bool `json:"move_to_root"`
+}
+
+type GroupMembersRequest struct {
+	AddressIDs []uint64 `json:"address_ids" validate:"required,min=1"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type GroupResponse struct {
+	Id       uint64  `json:"id"`
+	Name     string  `json:"name"`
+	ParentId *uint64 `json:"parent_id"`
+	Path     string  `json:"path"`
+}
@@ -1,9 +1,120 @@
 package dto
 
+import "address-book-server/filter"
+
 type ListAddressQuery struct {
-	Page    int    `form:"page"`
-	Limit   int    `form:"limit"`
-	Search  string `form:"search"`
-	City    string `form:"city"`
-	Country string `form:"country"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	Sort  string `form:"sort"`
+
+	// After and Before are opaque cursors from a previous page's meta or
+	// Link header. Count defaults to true for page based requests and to
+	// false for cursor requests.
+	After  string `form:"after"`
+	Before string `form:"before"`
+	Count  *bool  `form:"count"`
+
+	// Fields is a comma separated sparse fieldset, e.g. "first_name,phone",
+	// drawn from the export field catalog. SelectFields is its parsed form;
+	// empty means every field.
+	Fields       string   `form:"fields"`
+	SelectFields []string `form:"-"`
+
+	Search string `form:"search"`
+	// SearchMode picks how Search matches: substring (default), fulltext,
+	// which ranks and highlights hits, or fuzzy, which tolerates typos.
+	// Threshold is the minimum similarity for fuzzy matches.
+	SearchMode string  `form:"search_mode" validate:"omitempty,oneof=substring fulltext fuzzy"`
+	Threshold  float64 `form:"threshold" validate:"omitempty,gt=0,lte=1"`
+	// Phonetic matches each word of Search against the sound-alike codes of
+	// the first and last name, so "Kathryn" finds "Catherine".
+	Phonetic bool `form:"phonetic"`
+
+	// Filter is an expression such as
+	// country eq "IN" and (city in ["Pune","Mumbai"] or pincode sw "41");
+	// see the filter package for the grammar. FilterExpr is its parsed form.
+	Filter     string      `form:"filter"`
+	FilterExpr filter.Node `form:"-"`
+
+	// Near is "lat,lng" and RadiusKm limits matches to that distance; BBox
+	// is "west,south,east,north" in degrees. Only geocoded contacts match.
+	// Point and Box are their parsed forms.
+	Near     string    `form:"near"`
+	RadiusKm float64   `form:"radius_km" validate:"omitempty,gt=0,lte=20040"`
+	BBox     string    `form:"bbox"`
+	Point    *GeoPoint `form:"-"`
+	Box      *GeoBox   `form:"-"`
+
+	City    string   `form:"city"`
+	Country string   `form:"country"`
+	Tags    []string `form:"tag"`
+	TagMode string   `form:"tag_mode" validate:"omitempty,oneof=any all"`
+
+	GroupID          uint64 `form:"group_id"`
+	IncludeSubgroups bool   `form:"include_subgroups"`
+
+	// EventFrom and EventTo are YYYY-MM-DD dates; contacts match when one of
+	// their yearly events falls inside the range.
+	EventType  string         `form:"event_type" validate:"omitempty,oneof=birthday anniversary custom"`
+	EventFrom  string         `form:"event_from"`
+	EventTo    string         `form:"event_to"`
+	EventRange *MonthDayRange `form:"-"`
+
+	// CustomFields holds cf[<key>]=<value> filters; gin cannot bind maps
+	// from query strings, so the controller fills it in.
+	CustomFields map[string]string `form:"-"`
+
+	// BookID is taken from the route, not the query string.
+	BookID uint64 `form:"-"`
+
+	// SortFields is Sort parsed by the service; Keyset holds the decoded
+	// cursor values, one per sort field.
+	SortFields   []SortField   `form:"-"`
+	Keyset       []interface{} `form:"-"`
+	KeysetBefore bool          `form:"-"`
+
+	// SearchKey, CityKey and CountryKey are Search, City and Country run
+	// through utils.SearchKey by the service.
+	SearchKey  string `form:"-"`
+	CityKey    string `form:"-"`
+	CountryKey string `form:"-"`
+
+	// PhoneDigits is Search as phone digits when it looks like a number,
+	// see phone.SearchDigits.
+	PhoneDigits string `form:"-"`
+
+	// CountryCode is Country resolved to its ISO 3166-1 alpha-2 code, empty
+	// when it names no known country.
+	CountryCode string `form:"-"`
+}
+
+type ListAddressMeta struct {
+	// Total is nil when counting was skipped.
+	Total      *int64
+	NextCursor string
+	PrevCursor string
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type GetAddressQuery struct {
+	Fields       string   `form:"fields"`
+	SelectFields []string `form:"-"`
+}
+
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// GeoBox is a map viewport. West is greater than East when the box crosses
+// the antimeridian.
+type GeoBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
 }
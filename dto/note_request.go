@@ -0,0 +1,9 @@
+package dto
+
+type NoteRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type UpdateAttachmentRequest struct {
+	FileName string `json:"file_name" validate:"required,max=255"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type NoteResponse struct {
+	Id        uint64    `json:"id"`
+	AddressId uint64    `json:"address_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AttachmentResponse struct {
+	Id          uint64    `json:"id"`
+	AddressId   uint64    `json:"address_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	DownloadURL string    `json:"download_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
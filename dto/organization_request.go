@@ -0,0 +1,18 @@
+package dto
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type OrganizationInviteRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type AcceptOrganizationInviteRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type OrganizationResponse struct {
+	Id   uint64 `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type OrganizationMemberResponse struct {
+	Id       uint64    `json:"id"`
+	UserId   uint64    `json:"user_id"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type OrganizationInviteResponse struct {
+	Id               uint64     `json:"id"`
+	OrganizationId   uint64     `json:"organization_id"`
+	OrganizationName string     `json:"organization_name,omitempty"`
+	Email            string     `json:"email"`
+	Role             string     `json:"role"`
+	Status           string     `json:"status"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	AcceptedAt       *time.Time `json:"accepted_at"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// PostalCodeResponse lists the places a postal code may belong to. There
+// is more than one when the code serves several localities. Coverage is
+// "sample" while only the bundled sample of postal codes is loaded and
+// "full" once a directory is.
+type PostalCodeResponse struct {
+	Country     string                `json:"country"`
+	CountryCode string                `json:"country_code"`
+	PostalCode  string                `json:"postal_code"`
+	Coverage    string                `json:"coverage"`
+	Places      []PostalPlaceResponse `json:"places"`
+}
+
+type PostalPlaceResponse struct {
+	City      string `json:"city"`
+	District  string `json:"district"`
+	State     string `json:"state"`
+	StateCode string `json:"state_code"`
+}
+
+// GeocoderResponse names the geocoder contacts are located with. Coverage
+// is "sample" for the offline geocoder with only its bundled gazetteer and
+// "full" otherwise.
+type GeocoderResponse struct {
+	Provider string `json:"provider"`
+	Coverage string `json:"coverage"`
+}
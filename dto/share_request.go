@@ -0,0 +1,14 @@
+package dto
+
+type CreateShareRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=viewer editor manager"`
+}
+
+type UpdateShareRequest struct {
+	Role string `json:"role" validate:"required,oneof=viewer editor manager"`
+}
+
+type AcceptShareRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type ShareResponse struct {
+	Id           uint64     `json:"id"`
+	OwnerId      uint64     `json:"owner_id"`
+	OwnerEmail   string     `json:"owner_email,omitempty"`
+	InviteeEmail string     `json:"invitee_email"`
+	GranteeId    *uint64    `json:"grantee_id"`
+	Role         string     `json:"role"`
+	Status       string     `json:"status"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	AcceptedAt   *time.Time `json:"accepted_at"`
+}
+
+type OwnerResponse struct {
+	Id    uint64 `json:"id"`
+	Email string `json:"email"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type CreateTagRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"omitempty,max=20"`
+}
+
+type UpdateTagRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Color *string `json:"color" validate:"omitempty,max=20"`
+}
+
+// BulkTagRequest tags or untags every listed contact with every listed
+// tag, so both lists are capped to bound the rows written.
+type BulkTagRequest struct {
+	TagIDs     []uint64 `json:"tag_ids" validate:"required,min=1,max=50"`
+	AddressIDs []uint64 `json:"address_ids" validate:"required,min=1,max=1000"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type TagResponse struct {
+	Id    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
@@ -1,6 +1,9 @@
 package error
 
-import "net/http"
+import (
+	"net/http"
+	"strconv"
+)
 
 type AppError struct {
 	StatusCode int `json:"-"`
@@ -35,6 +38,21 @@ func NewValidationError(details map[string]string) *AppError {
 	}
 }
 
+// NewFilterError reports a malformed filter expression. Details carry what
+// is wrong and the 1-based character position it refers to.
+func NewFilterError(message string, position int, err error) *AppError {
+	return &AppError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "INVALID_FILTER",
+		Message:    "Invalid filter expression",
+		Details: map[string]string{
+			"filter":   message,
+			"position": strconv.Itoa(position),
+		},
+		Err: err,
+	}
+}
+
 func BadRequest(message string, err error) *AppError {
 	return NewError(
 		http.StatusBadRequest,
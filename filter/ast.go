@@ -0,0 +1,52 @@
+package filter
+
+import "fmt"
+
+// Node is a parsed filter expression: a Logical, a Not or a Comparison.
+type Node interface {
+	node()
+}
+
+// Logical joins two expressions with "and" or "or".
+type Logical struct {
+	Op    string
+	Left  Node
+	Right Node
+}
+
+// Not negates an expression.
+type Not struct {
+	Expr Node
+}
+
+// Comparison tests one field against a value. Values holds the converted
+// operand, one element per list item for "in".
+type Comparison struct {
+	Field  Field
+	Name   string
+	Op     string
+	Values []interface{}
+	// DateOnly is set for time fields compared with a plain date, which
+	// stands for the whole day.
+	DateOnly bool
+	Pos      int
+}
+
+func (*Logical) node()    {}
+func (*Not) node()        {}
+func (*Comparison) node() {}
+
+// Error is a parse or validation error. Pos is the 1-based character
+// position in the expression the error refers to.
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,120 @@
+package filter
+
+import (
+	"strings"
+	"time"
+)
+
+var comparisonOperators = map[string]string{
+	"eq": "=",
+	"ne": "<>",
+	"gt": ">",
+	"ge": ">=",
+	"lt": "<",
+	"le": "<=",
+}
+
+// Compile turns a parsed expression into a SQL condition with "?"
+// placeholders, ready for gorm's Where. Values are never inlined.
+func Compile(node Node) (string, []interface{}) {
+	var c compiler
+	c.compile(node)
+	return c.sql.String(), c.args
+}
+
+type compiler struct {
+	sql  strings.Builder
+	args []interface{}
+}
+
+func (c *compiler) write(sql string, args ...interface{}) {
+	c.sql.WriteString(sql)
+	c.args = append(c.args, args...)
+}
+
+func (c *compiler) compile(node Node) {
+	switch n := node.(type) {
+	case *Logical:
+		c.write("(")
+		c.compile(n.Left)
+		c.write(" " + strings.ToUpper(n.Op) + " ")
+		c.compile(n.Right)
+		c.write(")")
+	case *Not:
+		c.write("NOT (")
+		c.compile(n.Expr)
+		c.write(")")
+	case *Comparison:
+		switch n.Field.Type {
+		case TextField:
+			c.text(n)
+		case TimeField:
+			c.time(n)
+		default:
+			c.number(n)
+		}
+	}
+}
+
+// text compares case-insensitively; NULL behaves like an empty string.
+func (c *compiler) text(n *Comparison) {
+	column := "LOWER(COALESCE(" + n.Field.Column + ", ''))"
+
+	switch n.Op {
+	case "in":
+		values := make([]string, len(n.Values))
+		for i, v := range n.Values {
+			values[i] = strings.ToLower(v.(string))
+		}
+		c.write(column+" IN ?", values)
+	case "sw":
+		c.write(column+" LIKE ?", escapeLike(strings.ToLower(n.Values[0].(string)))+"%")
+	case "ew":
+		c.write(column+" LIKE ?", "%"+escapeLike(strings.ToLower(n.Values[0].(string))))
+	case "co":
+		c.write(column+" LIKE ?", "%"+escapeLike(strings.ToLower(n.Values[0].(string)))+"%")
+	default:
+		c.write(column+" "+comparisonOperators[n.Op]+" ?", strings.ToLower(n.Values[0].(string)))
+	}
+}
+
+func (c *compiler) number(n *Comparison) {
+	if n.Op == "in" {
+		c.write(n.Field.Column+" IN ?", n.Values)
+		return
+	}
+	c.write(n.Field.Column+" "+comparisonOperators[n.Op]+" ?", n.Values[0])
+}
+
+// time treats a plain date as the whole UTC day, so "updated_at eq
+// 2026-01-01" matches any time that day and "gt" starts the day after.
+func (c *compiler) time(n *Comparison) {
+	value := n.Values[0].(time.Time)
+	column := n.Field.Column
+
+	if !n.DateOnly {
+		c.write(column+" "+comparisonOperators[n.Op]+" ?", value)
+		return
+	}
+
+	next := value.AddDate(0, 0, 1)
+
+	switch n.Op {
+	case "eq":
+		c.write("("+column+" >= ? AND "+column+" < ?)", value, next)
+	case "ne":
+		c.write("("+column+" < ? OR "+column+" >= ?)", value, next)
+	case "gt":
+		c.write(column+" >= ?", next)
+	case "le":
+		c.write(column+" < ?", next)
+	default:
+		c.write(column+" "+comparisonOperators[n.Op]+" ?", value)
+	}
+}
+
+// escapeLike makes % and _ in user input match literally. PostgreSQL uses
+// backslash as the default LIKE escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,97 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+	instant := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	text := "LOWER(COALESCE(city, ''))"
+
+	tests := []struct {
+		name  string
+		input string
+		sql   string
+		args  []interface{}
+	}{
+		{"date eq covers the day", `updated_at eq 2026-01-01`,
+			"(addresses.updated_at >= ? AND addresses.updated_at < ?)", []interface{}{day, nextDay}},
+		{"date ne excludes the day", `updated_at ne 2026-01-01`,
+			"(addresses.updated_at < ? OR addresses.updated_at >= ?)", []interface{}{day, nextDay}},
+		{"date gt starts the next day", `updated_at gt 2026-01-01`,
+			"addresses.updated_at >= ?", []interface{}{nextDay}},
+		{"date ge starts the day", `updated_at ge 2026-01-01`,
+			"addresses.updated_at >= ?", []interface{}{day}},
+		{"date lt ends before the day", `updated_at lt 2026-01-01`,
+			"addresses.updated_at < ?", []interface{}{day}},
+		{"date le ends with the day", `updated_at le 2026-01-01`,
+			"addresses.updated_at < ?", []interface{}{nextDay}},
+		{"quoted date", `updated_at le "2026-01-01"`,
+			"addresses.updated_at < ?", []interface{}{nextDay}},
+		{"timestamp eq is exact", `updated_at eq 2026-01-01T10:30:00Z`,
+			"addresses.updated_at = ?", []interface{}{instant}},
+		{"timestamp le is exact", `updated_at le 2026-01-01T10:30:00Z`,
+			"addresses.updated_at <= ?", []interface{}{instant}},
+
+		{"text eq is case-insensitive", `city eq "Pune"`,
+			text + " = ?", []interface{}{"pune"}},
+		{"text eq does not escape", `city eq "a_b%"`,
+			text + " = ?", []interface{}{"a_b%"}},
+		{"sw escapes percent and underscore", `city sw "50%_off"`,
+			text + " LIKE ?", []interface{}{`50\%\_off%`}},
+		{"ew escapes underscore", `city ew "_x"`,
+			text + " LIKE ?", []interface{}{`%\_x`}},
+		{"co escapes backslash", `city co "a\\b"`,
+			text + " LIKE ?", []interface{}{`%a\\b%`}},
+		{"text in", `city in ["Pune", "MUMBAI"]`,
+			text + " IN ?", []interface{}{[]string{"pune", "mumbai"}}},
+
+		{"number", `id ge 10`,
+			"addresses.id >= ?", []interface{}{int64(10)}},
+		{"number in", `id in [1, 2]`,
+			"addresses.id IN ?", []interface{}{[]interface{}{int64(1), int64(2)}}},
+
+		{"logical grouping", `not (city eq "a" or id gt 3) and pincode sw "41"`,
+			"(NOT ((" + text + " = ? OR addresses.id > ?)) AND LOWER(COALESCE(pincode, '')) LIKE ?)",
+			[]interface{}{"a", int64(3), "41%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input, testFields)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+
+			sql, args := Compile(node)
+			if sql != tt.sql {
+				t.Errorf("Compile(%q) sql = %q, want %q", tt.input, sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Compile(%q) args = %#v, want %#v", tt.input, args, tt.args)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"plain":   "plain",
+		"100%":    `100\%`,
+		"a_b":     `a\_b`,
+		`c:\temp`: `c:\\temp`,
+		`\%_`:     `\\\%\_`,
+		"":        "",
+	}
+
+	for input, want := range tests {
+		if got := escapeLike(input); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
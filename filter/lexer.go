@@ -0,0 +1,128 @@
+// Package filter parses the list endpoints' filter expressions, such as
+//
+//	country eq "IN" and (city in ["Pune","Mumbai"] or pincode sw "41")
+//
+// into an AST that is checked against a whitelist of fields and compiled to
+// a parameterized SQL condition.
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenLiteral
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos is the 1-based character position of the token in the input.
+	pos int
+}
+
+// describe names a token for error messages.
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return `"` + t.text + `"`
+	}
+	return t.text
+}
+
+// lex splits input into tokens. Identifiers are field names, keywords and
+// operators; literals are unquoted numbers and dates like 2026-01-01.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{kind: tokenLBracket, text: "[", pos: pos})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{kind: tokenRBracket, text: "]", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+		case r == '"':
+			text, next, err := lexString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			i = next
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: pos})
+		case unicode.IsDigit(r) || ((r == '-' || r == '+') && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && isLiteralRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenLiteral, text: string(runes[start:i]), pos: pos})
+		default:
+			return nil, errorf(pos, "Unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+// isLiteralRune accepts the characters of numbers and RFC 3339 timestamps.
+func isLiteralRune(r rune) bool {
+	return unicode.IsDigit(r) || strings.ContainsRune(".:-+TZ", r)
+}
+
+// lexString reads a double-quoted string starting at runes[start]. A
+// backslash escapes the next character.
+func lexString(runes []rune, start int) (string, int, error) {
+	var b strings.Builder
+
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 == len(runes) {
+				return "", 0, errorf(i+1, "Unterminated escape sequence")
+			}
+			i++
+			b.WriteRune(runes[i])
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, errorf(start+1, "Unterminated string")
+}
@@ -0,0 +1,289 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType decides which operators and literals a field accepts.
+type FieldType int
+
+const (
+	TextField FieldType = iota
+	NumberField
+	TimeField
+)
+
+// Field maps a filterable name to the SQL column behind it.
+type Field struct {
+	Column string
+	Type   FieldType
+}
+
+// Limits keep a single expression from producing an unbounded query.
+const (
+	MaxLength      = 2000
+	MaxDepth       = 16
+	MaxComparisons = 32
+	MaxListItems   = 100
+)
+
+var operators = map[FieldType][]string{
+	TextField:   {"eq", "ne", "sw", "ew", "co", "in"},
+	NumberField: {"eq", "ne", "gt", "ge", "lt", "le", "in"},
+	TimeField:   {"eq", "ne", "gt", "ge", "lt", "le"},
+}
+
+// Parse parses input and checks every comparison against fields. Keywords
+// and operators are case-insensitive; "and" binds tighter than "or".
+//
+//	expr       = term { "or" term }
+//	term       = factor { "and" factor }
+//	factor     = "not" factor | "(" expr ")" | comparison
+//	comparison = field operator value
+//	value      = string | number | date | "[" value { "," value } "]"
+func Parse(input string, fields map[string]Field) (Node, error) {
+	if len([]rune(input)) > MaxLength {
+		return nil, errorf(MaxLength+1, "Filter is longer than %d characters", MaxLength)
+	}
+
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(p.peek().pos, "Filter is empty")
+	}
+
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, errorf(next.pos, "Expected \"and\", \"or\" or end of filter, found %s", next.describe())
+	}
+
+	return node, nil
+}
+
+type parser struct {
+	tokens      []token
+	index       int
+	fields      map[string]Field
+	comparisons int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the given keyword and consumes
+// it if so.
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == tokenIdent && strings.EqualFold(t.text, word) {
+		p.index++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr(depth int) (Node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "or", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (Node, error) {
+	left, err := p.parseFactor(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		right, err := p.parseFactor(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "and", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseFactor(depth int) (Node, error) {
+	if depth >= MaxDepth {
+		return nil, errorf(p.peek().pos, "Filter is nested deeper than %d levels", MaxDepth)
+	}
+
+	if p.keyword("not") {
+		expr, err := p.parseFactor(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		open := p.next()
+
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, errorf(t.pos, "Expected \")\" to close \"(\" at position %d, found %s", open.pos, t.describe())
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	name := p.next()
+	if name.kind != tokenIdent {
+		return nil, errorf(name.pos, "Expected a field name, found %s", name.describe())
+	}
+
+	field, ok := p.fields[strings.ToLower(name.text)]
+	if !ok {
+		return nil, errorf(name.pos, "Unknown field %q", name.text)
+	}
+
+	p.comparisons++
+	if p.comparisons > MaxComparisons {
+		return nil, errorf(name.pos, "Filter has more than %d comparisons", MaxComparisons)
+	}
+
+	opToken := p.next()
+	if opToken.kind != tokenIdent {
+		return nil, errorf(opToken.pos, "Expected an operator after %q, found %s", name.text, opToken.describe())
+	}
+
+	op := strings.ToLower(opToken.text)
+	if !supports(field.Type, op) {
+		return nil, errorf(opToken.pos, "Operator %q is not supported for field %q", opToken.text, name.text)
+	}
+
+	comparison := &Comparison{Field: field, Name: strings.ToLower(name.text), Op: op, Pos: name.pos}
+
+	if op == "in" {
+		values, err := p.parseList(field)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = values
+		return comparison, nil
+	}
+
+	value, dateOnly, err := p.parseValue(field)
+	if err != nil {
+		return nil, err
+	}
+	comparison.Values = []interface{}{value}
+	comparison.DateOnly = dateOnly
+
+	return comparison, nil
+}
+
+func supports(fieldType FieldType, op string) bool {
+	for _, allowed := range operators[fieldType] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseList(field Field) ([]interface{}, error) {
+	open := p.next()
+	if open.kind != tokenLBracket {
+		return nil, errorf(open.pos, "Expected \"[\" to start a list, found %s", open.describe())
+	}
+
+	var values []interface{}
+
+	for {
+		value, _, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+		if len(values) > MaxListItems {
+			return nil, errorf(open.pos, "List has more than %d items", MaxListItems)
+		}
+
+		t := p.next()
+		switch t.kind {
+		case tokenComma:
+			continue
+		case tokenRBracket:
+			return values, nil
+		}
+		return nil, errorf(t.pos, "Expected \",\" or \"]\", found %s", t.describe())
+	}
+}
+
+// parseValue reads one operand and converts it to the field's type. For
+// time fields it also reports whether the value was a date without a time.
+func (p *parser) parseValue(field Field) (interface{}, bool, error) {
+	t := p.next()
+
+	switch field.Type {
+	case TextField:
+		if t.kind != tokenString {
+			return nil, false, errorf(t.pos, "Expected a quoted string, found %s", t.describe())
+		}
+		return t.text, false, nil
+
+	case NumberField:
+		if t.kind != tokenLiteral {
+			return nil, false, errorf(t.pos, "Expected a number, found %s", t.describe())
+		}
+		number, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return nil, false, errorf(t.pos, "Invalid number %q", t.text)
+		}
+		return number, false, nil
+
+	case TimeField:
+		if t.kind != tokenLiteral && t.kind != tokenString {
+			return nil, false, errorf(t.pos, "Expected a date, found %s", t.describe())
+		}
+		if date, err := time.Parse("2006-01-02", t.text); err == nil {
+			return date, true, nil
+		}
+		if instant, err := time.Parse(time.RFC3339, t.text); err == nil {
+			return instant, false, nil
+		}
+		return nil, false, errorf(t.pos, "Invalid date %q, expected YYYY-MM-DD or RFC 3339", t.text)
+	}
+
+	return nil, false, errorf(t.pos, "Unsupported field type")
+}
@@ -0,0 +1,165 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var testFields = map[string]Field{
+	"city":       {Column: "city", Type: TextField},
+	"country":    {Column: "country", Type: TextField},
+	"pincode":    {Column: "pincode", Type: TextField},
+	"id":         {Column: "addresses.id", Type: NumberField},
+	"updated_at": {Column: "addresses.updated_at", Type: TimeField},
+}
+
+// shape prints the structure of a parsed expression, naming comparisons by
+// field, so tests can assert how it was grouped.
+func shape(node Node) string {
+	switch n := node.(type) {
+	case *Logical:
+		return "(" + n.Op + " " + shape(n.Left) + " " + shape(n.Right) + ")"
+	case *Not:
+		return "(not " + shape(n.Expr) + ")"
+	case *Comparison:
+		return n.Name
+	}
+	return "?"
+}
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"and binds tighter than or", `city eq "a" or country eq "b" and pincode eq "c"`, "(or city (and country pincode))"},
+		{"and before or", `city eq "a" and country eq "b" or pincode eq "c"`, "(or (and city country) pincode)"},
+		{"or is left associative", `city eq "a" or country eq "b" or pincode eq "c"`, "(or (or city country) pincode)"},
+		{"not binds tighter than and", `not city eq "a" and country eq "b"`, "(and (not city) country)"},
+		{"not of a group", `not (city eq "a" or country eq "b")`, "(not (or city country))"},
+		{"parentheses override precedence", `(city eq "a" or country eq "b") and pincode eq "c"`, "(and (or city country) pincode)"},
+		{"double negation", `not not city eq "a"`, "(not (not city))"},
+		{"keywords are case-insensitive", `City EQ "a" AND country Eq "b" Or pincode eq "c"`, "(or (and city country) pincode)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input, testFields)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if got := shape(node); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	node, err := Parse(`city in ["Pune", "Mumbai"] and id in [1, -2] and country eq "say \"hi\""`, testFields)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	and := node.(*Logical)
+	cities := and.Left.(*Logical).Left.(*Comparison)
+	ids := and.Left.(*Logical).Right.(*Comparison)
+	country := and.Right.(*Comparison)
+
+	if len(cities.Values) != 2 || cities.Values[0] != "Pune" || cities.Values[1] != "Mumbai" {
+		t.Errorf("city values = %v, want [Pune Mumbai]", cities.Values)
+	}
+	if len(ids.Values) != 2 || ids.Values[0] != int64(1) || ids.Values[1] != int64(-2) {
+		t.Errorf("id values = %v, want [1 -2]", ids.Values)
+	}
+	if country.Values[0] != `say "hi"` {
+		t.Errorf("country value = %q, want %q", country.Values[0], `say "hi"`)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat("(", depth) + `city eq "a"` + strings.Repeat(")", depth)
+	}
+
+	list := func(items int) string {
+		values := make([]string, items)
+		for i := range values {
+			values[i] = strconv.Itoa(i)
+		}
+		return "id in [" + strings.Join(values, ",") + "]"
+	}
+
+	comparisons := func(count int) string {
+		terms := make([]string, count)
+		for i := range terms {
+			terms[i] = "id eq 1"
+		}
+		return strings.Join(terms, " or ")
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		pos     int
+		message string
+	}{
+		{"empty", "", 1, "Filter is empty"},
+		{"blank", "   ", 4, "Filter is empty"},
+		{"too long", strings.Repeat(" ", MaxLength+1), MaxLength + 1, "Filter is longer than 2000 characters"},
+		{"unterminated string", `city eq "Pune`, 9, "Unterminated string"},
+		{"unterminated escape", `city eq "Pune\`, 14, "Unterminated escape sequence"},
+		{"unexpected character", `city eq 'Pune'`, 9, `Unexpected character '\''`},
+		{"unknown field", `city eq "a" and town eq "b"`, 17, `Unknown field "town"`},
+		{"operator not supported for text", `city gt "a"`, 6, `Operator "gt" is not supported for field "city"`},
+		{"operator not supported for time", `updated_at in [2026-01-01]`, 12, `Operator "in" is not supported for field "updated_at"`},
+		{"missing operator", `city`, 5, `Expected an operator after "city", found end of filter`},
+		{"missing value", `city eq`, 8, "Expected a quoted string, found end of filter"},
+		{"text needs quotes", `city eq Pune`, 9, "Expected a quoted string, found Pune"},
+		{"number expected", `id eq "1"`, 7, `Expected a number, found "1"`},
+		{"invalid number", `id eq 1.5`, 7, `Invalid number "1.5"`},
+		{"invalid date", `updated_at gt 2026-13-01`, 15, `Invalid date "2026-13-01", expected YYYY-MM-DD or RFC 3339`},
+		{"missing list", `city in "a"`, 9, `Expected "[" to start a list, found "a"`},
+		{"unclosed list", `city in ["a" "b"]`, 14, `Expected "," or "]", found "b"`},
+		{"unclosed group", `(city eq "a"`, 13, `Expected ")" to close "(" at position 1, found end of filter`},
+		{"missing connective", `city eq "a" country eq "b"`, 13, `Expected "and", "or" or end of filter, found country`},
+		{"dangling connective", `city eq "a" and`, 16, "Expected a field name, found end of filter"},
+		{"too deep", nested(MaxDepth), MaxDepth + 1, "Filter is nested deeper than 16 levels"},
+		{"too deep with not", strings.Repeat("not ", MaxDepth) + `city eq "a"`, 4*MaxDepth + 1, "Filter is nested deeper than 16 levels"},
+		{"too many items", list(MaxListItems + 1), 7, "List has more than 100 items"},
+		{"too many comparisons", comparisons(MaxComparisons + 1), 11*MaxComparisons + 1, "Filter has more than 32 comparisons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input, testFields)
+			if err == nil {
+				t.Fatalf("Parse(%q) returned no error", tt.input)
+			}
+
+			filterErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("Parse(%q) returned %T, want *Error", tt.input, err)
+			}
+			if filterErr.Pos != tt.pos || filterErr.Message != tt.message {
+				t.Errorf("Parse(%q) = %q at %d, want %q at %d", tt.input, filterErr.Message, filterErr.Pos, tt.message, tt.pos)
+			}
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	inputs := []string{
+		strings.Repeat("(", MaxDepth-1) + `city eq "a"` + strings.Repeat(")", MaxDepth-1),
+		"id in [" + strings.TrimSuffix(strings.Repeat("1,", MaxListItems), ",") + "]",
+		strings.TrimSuffix(strings.Repeat("id eq 1 or ", MaxComparisons), " or "),
+	}
+
+	for _, input := range inputs {
+		if _, err := Parse(input, testFields); err != nil {
+			t.Errorf("Parse at the limit returned error: %v", err)
+		}
+	}
+}
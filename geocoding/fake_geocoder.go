@@ -0,0 +1,55 @@
+package geocoding
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// FakeGeocoder answers from a fixed table keyed by pincode or by city, both
+// compared case-insensitively, and records every query. It is meant for
+// tests and local development.
+type FakeGeocoder struct {
+	mu      sync.Mutex
+	results map[string]Result
+	queries []Query
+
+	// Err, when set, is returned for every query.
+	Err error
+}
+
+func NewFakeGeocoder(results map[string]Result) *FakeGeocoder {
+	table := make(map[string]Result, len(results))
+	for key, result := range results {
+		table[strings.ToLower(key)] = result
+	}
+
+	return &FakeGeocoder{results: table}
+}
+
+func (g *FakeGeocoder) Geocode(ctx context.Context, query Query) (*Result, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.queries = append(g.queries, query)
+
+	if g.Err != nil {
+		return nil, g.Err
+	}
+
+	for _, key := range []string{query.Pincode, query.City} {
+		if result, ok := g.results[strings.ToLower(key)]; ok && key != "" {
+			return &result, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// Queries returns the queries received so far.
+func (g *FakeGeocoder) Queries() []Query {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]Query(nil), g.queries...)
+}
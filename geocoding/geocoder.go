@@ -0,0 +1,68 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a provider has no match for an address.
+var ErrNotFound = errors.New("address not found")
+
+// Accuracy levels, from most to least precise.
+const (
+	AccuracyAddress  = "address"
+	AccuracyStreet   = "street"
+	AccuracyPostcode = "postcode"
+	AccuracyCity     = "city"
+	AccuracyRegion   = "region"
+	AccuracyCountry  = "country"
+)
+
+// Query is the postal address to locate. Empty parts are ignored.
+type Query struct {
+	AddressLine1 string
+	AddressLine2 string
+	City         string
+	State        string
+	Country      string
+	Pincode      string
+}
+
+// Empty reports whether there is nothing to geocode.
+func (q Query) Empty() bool {
+	return q.AddressLine1 == "" && q.AddressLine2 == "" && q.City == "" &&
+		q.State == "" && q.Country == "" && q.Pincode == ""
+}
+
+// Result is a located address. Accuracy says how precise the point is.
+type Result struct {
+	Latitude  float64
+	Longitude float64
+	Accuracy  string
+}
+
+// Geocoder turns postal addresses into coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, query Query) (*Result, error)
+}
+
+// Coverage values, how much of the world a geocoder can place.
+const (
+	CoverageSample = "sample"
+	CoverageFull   = "full"
+)
+
+// CoverageReporter is implemented by geocoders that only know part of the
+// world.
+type CoverageReporter interface {
+	Coverage() string
+}
+
+// CoverageOf reports the coverage of g. Geocoders that do not report it
+// search a full provider.
+func CoverageOf(g Geocoder) string {
+	if reporter, ok := g.(CoverageReporter); ok {
+		return reporter.Coverage()
+	}
+	return CoverageFull
+}
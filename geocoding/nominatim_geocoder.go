@@ -0,0 +1,152 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultNominatimURL is the public OpenStreetMap instance. Its usage policy
+// allows one request per second and requires an identifying User-Agent.
+const DefaultNominatimURL = "https://nominatim.openstreetmap.org"
+
+type nominatimGeocoder struct {
+	baseURL   string
+	userAgent string
+	email     string
+	client    *http.Client
+
+	// Requests are spaced at least interval apart.
+	mu       sync.Mutex
+	last     time.Time
+	interval time.Duration
+}
+
+type nominatimPlace struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	AddressType string `json:"addresstype"`
+	PlaceRank   int    `json:"place_rank"`
+}
+
+// NewNominatimGeocoder talks to a Nominatim compatible search API at
+// baseURL. email is sent along as the contact the usage policy asks for.
+func NewNominatimGeocoder(baseURL, userAgent, email string) Geocoder {
+	if baseURL == "" {
+		baseURL = DefaultNominatimURL
+	}
+
+	return &nominatimGeocoder{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userAgent: userAgent,
+		email:     email,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		interval:  time.Second,
+	}
+}
+
+func (g *nominatimGeocoder) Geocode(ctx context.Context, query Query) (*Result, error) {
+	if query.Empty() {
+		return nil, ErrNotFound
+	}
+
+	params := url.Values{}
+	params.Set("format", "jsonv2")
+	params.Set("limit", "1")
+	setIfPresent(params, "street", strings.TrimSpace(query.AddressLine1+" "+query.AddressLine2))
+	setIfPresent(params, "city", query.City)
+	setIfPresent(params, "state", query.State)
+	setIfPresent(params, "country", query.Country)
+	setIfPresent(params, "postalcode", query.Pincode)
+	setIfPresent(params, "email", g.email)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if g.userAgent != "" {
+		req.Header.Set("User-Agent", g.userAgent)
+	}
+
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nominatim returned %s", resp.Status)
+	}
+
+	var places []nominatimPlace
+	if err := json.NewDecoder(resp.Body).Decode(&places); err != nil {
+		return nil, err
+	}
+
+	if len(places) == 0 {
+		return nil, ErrNotFound
+	}
+
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return nil, err
+	}
+	lng, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Latitude: lat, Longitude: lng, Accuracy: nominatimAccuracy(places[0])}, nil
+}
+
+// wait blocks until the next request may be sent.
+func (g *nominatimGeocoder) wait(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if delay := time.Until(g.last.Add(g.interval)); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	g.last = time.Now()
+	return nil
+}
+
+// nominatimAccuracy maps Nominatim's place rank, 30 for a building down to 4
+// for a country, onto our accuracy levels.
+func nominatimAccuracy(place nominatimPlace) string {
+	switch {
+	case place.AddressType == "postcode":
+		return AccuracyPostcode
+	case place.PlaceRank >= 28:
+		return AccuracyAddress
+	case place.PlaceRank >= 26:
+		return AccuracyStreet
+	case place.PlaceRank >= 13:
+		return AccuracyCity
+	case place.PlaceRank >= 5:
+		return AccuracyRegion
+	}
+	return AccuracyCountry
+}
+
+func setIfPresent(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
@@ -0,0 +1,170 @@
+package geocoding
+
+import (
+	"address-book-server/utils"
+
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The bundled gazetteer is a sample: the centroids of about twenty
+// countries, their largest cities and a few of their postcodes. Anything
+// else only places as precisely as its country, or not at all, which
+// Coverage reports. Deployments geocoding offline load a full gazetteer in
+// the same format instead, named by GAZETTEER_FILE.
+//
+//go:embed data/gazetteer.csv
+var gazetteerCSV []byte
+
+type gazetteerEntry struct {
+	country string
+	city    string
+	state   string
+	point   Result
+}
+
+// offlineGeocoder resolves addresses from a gazetteer of country, city and
+// postcode centroids, so it never leaves the process. It can only be as
+// precise as a postcode.
+type offlineGeocoder struct {
+	coverage  string
+	countries map[string]gazetteerEntry
+	cities    map[string][]gazetteerEntry
+	postcodes map[string][]gazetteerEntry
+}
+
+// NewOfflineGeocoder loads the gazetteer at path, a CSV file with the
+// columns kind, country, key, city, state, latitude and longitude, or the
+// bundled sample when path is empty.
+func NewOfflineGeocoder(path string) (Geocoder, error) {
+	if path == "" {
+		return readGazetteer(bytes.NewReader(gazetteerCSV), CoverageSample)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := readGazetteer(f, CoverageFull)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return g, nil
+}
+
+func readGazetteer(r io.Reader, coverage string) (*offlineGeocoder, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("gazetteer is empty")
+	}
+
+	g := &offlineGeocoder{
+		coverage:  coverage,
+		countries: map[string]gazetteerEntry{},
+		cities:    map[string][]gazetteerEntry{},
+		postcodes: map[string][]gazetteerEntry{},
+	}
+
+	for i, record := range records[1:] {
+		if len(record) != 7 {
+			return nil, fmt.Errorf("gazetteer line %d: expected 7 columns, got %d", i+2, len(record))
+		}
+
+		lat, latErr := strconv.ParseFloat(record[5], 64)
+		lng, lngErr := strconv.ParseFloat(record[6], 64)
+		if latErr != nil || lngErr != nil {
+			return nil, fmt.Errorf("gazetteer line %d: invalid coordinates", i+2)
+		}
+
+		entry := gazetteerEntry{country: record[1], city: record[3], state: record[4], point: Result{Latitude: lat, Longitude: lng}}
+
+		switch record[0] {
+		case "country":
+			entry.point.Accuracy = AccuracyCountry
+			g.countries[utils.SearchKey(record[1])] = entry
+			g.countries[utils.SearchKey(record[2])] = entry
+		case "city":
+			entry.point.Accuracy = AccuracyCity
+			key := utils.SearchKey(record[2])
+			g.cities[key] = append(g.cities[key], entry)
+		case "postcode":
+			entry.point.Accuracy = AccuracyPostcode
+			key := postcodeKey(record[2])
+			g.postcodes[key] = append(g.postcodes[key], entry)
+		default:
+			return nil, fmt.Errorf("gazetteer line %d: unknown kind %q", i+2, record[0])
+		}
+	}
+
+	return g, nil
+}
+
+// Coverage reports whether the gazetteer is the bundled sample.
+func (g *offlineGeocoder) Coverage() string {
+	return g.coverage
+}
+
+// Geocode tries the postcode, then the city, then the country. When the
+// country is unknown a postcode or city name must be unambiguous.
+func (g *offlineGeocoder) Geocode(ctx context.Context, query Query) (*Result, error) {
+	country := ""
+	if entry, ok := g.countries[utils.SearchKey(query.Country)]; ok {
+		country = entry.country
+	}
+
+	if query.Pincode != "" {
+		if entry, ok := pick(g.postcodes[postcodeKey(query.Pincode)], country); ok {
+			return &entry.point, nil
+		}
+	}
+
+	if query.City != "" {
+		if entry, ok := pick(g.cities[utils.SearchKey(query.City)], country); ok {
+			return &entry.point, nil
+		}
+	}
+
+	if country != "" {
+		point := g.countries[utils.SearchKey(country)].point
+		return &point, nil
+	}
+
+	return nil, ErrNotFound
+}
+
+// pick returns the entry in country, or the only entry when country is
+// unknown.
+func pick(entries []gazetteerEntry, country string) (gazetteerEntry, bool) {
+	if country == "" {
+		if len(entries) == 1 {
+			return entries[0], true
+		}
+		return gazetteerEntry{}, false
+	}
+
+	for _, entry := range entries {
+		if entry.country == country {
+			return entry, true
+		}
+	}
+
+	return gazetteerEntry{}, false
+}
+
+// postcodeKey ignores case and spacing, so "sw1a 1aa" finds SW1A1AA.
+func postcodeKey(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
@@ -0,0 +1,71 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOfflineGeocoderSample(t *testing.T) {
+	g, err := NewOfflineGeocoder("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := CoverageOf(g); got != CoverageSample {
+		t.Errorf("CoverageOf(sample) = %q, want %q", got, CoverageSample)
+	}
+
+	result, err := g.Geocode(context.Background(), Query{City: "mumbai", Country: "India"})
+	if err != nil || result.Accuracy != AccuracyCity {
+		t.Errorf("Geocode(Mumbai) = %+v, %v, want a city match", result, err)
+	}
+
+	// A town outside the sample only places at its country.
+	result, err = g.Geocode(context.Background(), Query{City: "Hubballi", Country: "India"})
+	if err != nil || result.Accuracy != AccuracyCountry {
+		t.Errorf("Geocode(Hubballi) = %+v, %v, want the country centroid", result, err)
+	}
+}
+
+func TestOfflineGeocoderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gazetteer.csv")
+	gazetteer := "kind,country,key,city,state,latitude,longitude\n" +
+		"country,IN,India,,,22.3511,78.6677\n" +
+		"city,IN,Hubballi,Hubballi,Karnataka,15.3647,75.1240\n" +
+		"postcode,IN,580020,Hubballi,Karnataka,15.3500,75.1380\n"
+	if err := os.WriteFile(path, []byte(gazetteer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewOfflineGeocoder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := CoverageOf(g); got != CoverageFull {
+		t.Errorf("CoverageOf(file) = %q, want %q", got, CoverageFull)
+	}
+
+	result, err := g.Geocode(context.Background(), Query{Pincode: "580 020"})
+	if err != nil || result.Accuracy != AccuracyPostcode || result.Latitude != 15.35 {
+		t.Errorf("Geocode(580 020) = %+v, %v, want the postcode centroid", result, err)
+	}
+
+	// The file replaces the sample.
+	if _, err := g.Geocode(context.Background(), Query{City: "Mumbai"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Geocode(Mumbai) error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := NewOfflineGeocoder(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("NewOfflineGeocoder accepted a missing file")
+	}
+}
+
+func TestCoverageOf(t *testing.T) {
+	if got := CoverageOf(NewFakeGeocoder(nil)); got != CoverageFull {
+		t.Errorf("CoverageOf(fake) = %q, want %q", got, CoverageFull)
+	}
+}
@@ -0,0 +1,14 @@
+package mapper
+
+import (
+	"address-book-server/dto"
+	"address-book-server/model"
+)
+
+func ToAddressBookResponse(book model.AddressBook) dto.AddressBookResponse {
+	return dto.AddressBookResponse{
+		Id:        book.ID,
+		Name:      book.Name,
+		IsDefault: book.IsDefault,
+	}
+}
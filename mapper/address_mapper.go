@@ -3,21 +3,163 @@ package mapper
 import (
 	"address-book-server/dto"
 	"address-book-server/model"
+	"address-book-server/utils"
+
+	"fmt"
 )
 
+// PhotoURL is where the photo of a contact is served, under its book so
+// that the book's access rules apply.
+func PhotoURL(bookId, addressId uint64) string {
+	return fmt.Sprintf("/api/v1/books/%d/addresses/%d/photo", bookId, addressId)
+}
+
 func ToListAddressResponse(address model.Address) dto.ListAddressResponse {
+	tags := make([]string, 0, len(address.Tags))
+	for _, t := range address.Tags {
+		tags = append(tags, t.Name)
+	}
+
+	var photoURL, thumbnailURL string
+	if address.PhotoKey != "" {
+		photoURL = PhotoURL(address.BookID, address.ID)
+		thumbnailURL = photoURL + "?size=thumbnail"
+	}
+
 	return dto.ListAddressResponse{
 		Id:           address.ID,
 		UserId:       address.UserID,
+		BookId:       address.BookID,
 		FirstName:    address.FirstName,
 		LastName:     address.LastName,
 		Email:        address.Email,
 		Phone:        address.Phone,
+		PhoneE164:    address.PhoneE164,
 		AddressLine1: address.AddressLine1,
 		AddressLine2: address.AddressLine2,
 		City:         address.City,
 		State:        address.State,
 		Country:      address.Country,
 		Pincode:      address.Pincode,
+		CountryCode:  address.CountryCode,
+		StateCode:    address.StateCode,
+		PostalCode:   address.PostalCode,
+		Tags:         tags,
+		PhotoURL:     photoURL,
+		ThumbnailURL: thumbnailURL,
+
+		Emails:          ToEmailResponses(address.Emails),
+		Phones:          ToPhoneResponses(address.Phones),
+		PostalAddresses: ToPostalAddressResponses(address.PostalAddresses),
+
+		CustomFields: ToCustomFieldMap(address.CustomFieldValues),
+
+		Events: ToEventResponses(address.Events),
+
+		Location: dto.LocationResponse{
+			Latitude:   address.Latitude,
+			Longitude:  address.Longitude,
+			Status:     address.GeocodeStatus,
+			Accuracy:   address.GeocodeAccuracy,
+			GeocodedAt: address.GeocodedAt,
+		},
+	}
+}
+
+// ToSparseAddressResponse keeps only the id and the requested fields of a
+// response, plus the search hit when there is one.
+func ToSparseAddressResponse(r dto.ListAddressResponse, fields []string) map[string]interface{} {
+	sparse := map[string]interface{}{"id": r.Id}
+
+	for _, f := range fields {
+		switch f {
+		case "user_id":
+			sparse[f] = r.UserId
+		case "first_name":
+			sparse[f] = r.FirstName
+		case "last_name":
+			sparse[f] = r.LastName
+		case "email":
+			sparse[f] = r.Email
+		case "phone":
+			sparse[f] = r.Phone
+		case "phone_e164":
+			sparse[f] = r.PhoneE164
+		case "address_line1":
+			sparse[f] = r.AddressLine1
+		case "address_line2":
+			sparse[f] = r.AddressLine2
+		case "city":
+			sparse[f] = r.City
+		case "state":
+			sparse[f] = r.State
+		case "country":
+			sparse[f] = r.Country
+		case "pincode":
+			sparse[f] = r.Pincode
+		case "country_code":
+			sparse[f] = r.CountryCode
+		case "state_code":
+			sparse[f] = r.StateCode
+		case "postal_code":
+			sparse[f] = r.PostalCode
+		case "tags":
+			sparse[f] = r.Tags
+		case "emails":
+			sparse[f] = r.Emails
+		case "phones":
+			sparse[f] = r.Phones
+		case "postal_addresses":
+			sparse[f] = r.PostalAddresses
+		}
 	}
+
+	if r.Search != nil {
+		sparse["search"] = r.Search
+	}
+	if r.DistanceKm != nil {
+		sparse["distance_km"] = r.DistanceKm
+	}
+
+	return sparse
+}
+
+func ToTagResponse(tag model.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		Id:    tag.ID,
+		Name:  tag.Name,
+		Color: tag.Color,
+	}
+}
+
+func ToAddressModel(req *dto.CreateAddressRequest) model.Address {
+	return model.Address{
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		Email:           req.Email,
+		Phone:           req.Phone,
+		AddressLine1:    req.AddressLine1,
+		AddressLine2:    req.AddressLine2,
+		City:            req.City,
+		State:           req.State,
+		Country:         req.Country,
+		Pincode:         req.Pincode,
+		Emails:          ToAddressEmails(req.Emails),
+		Phones:          ToAddressPhones(req.Phones),
+		PostalAddresses: ToPostalAddresses(req.PostalAddresses),
+	}
+}
+
+func ToEventResponses(events []model.ContactEvent) []dto.EventResponse {
+	response := make([]dto.EventResponse, 0, len(events))
+
+	for _, e := range events {
+		response = append(response, dto.EventResponse{
+			Type:  e.Type,
+			Label: e.Label,
+			Date:  utils.FormatEventDate(e.Month, e.Day, e.Year),
+		})
+	}
+
+	return response
 }
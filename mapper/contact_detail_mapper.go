@@ -0,0 +1,110 @@
+package mapper
+
+import (
+	"address-book-server/dto"
+	"address-book-server/model"
+)
+
+func ToAddressEmails(requests []dto.EmailRequest) []model.AddressEmail {
+	emails := make([]model.AddressEmail, 0, len(requests))
+
+	for _, r := range requests {
+		emails = append(emails, model.AddressEmail{
+			Type:      r.Type,
+			Label:     r.Label,
+			Email:     r.Email,
+			IsPrimary: r.IsPrimary,
+		})
+	}
+
+	return emails
+}
+
+func ToAddressPhones(requests []dto.PhoneRequest) []model.AddressPhone {
+	phones := make([]model.AddressPhone, 0, len(requests))
+
+	for _, r := range requests {
+		phones = append(phones, model.AddressPhone{
+			Type:      r.Type,
+			Label:     r.Label,
+			Phone:     r.Phone,
+			IsPrimary: r.IsPrimary,
+		})
+	}
+
+	return phones
+}
+
+func ToPostalAddresses(requests []dto.PostalAddressRequest) []model.PostalAddress {
+	postals := make([]model.PostalAddress, 0, len(requests))
+
+	for _, r := range requests {
+		postals = append(postals, model.PostalAddress{
+			Type:         r.Type,
+			Label:        r.Label,
+			AddressLine1: r.AddressLine1,
+			AddressLine2: r.AddressLine2,
+			City:         r.City,
+			State:        r.State,
+			Country:      r.Country,
+			Pincode:      r.Pincode,
+			IsPrimary:    r.IsPrimary,
+		})
+	}
+
+	return postals
+}
+
+func ToEmailResponses(emails []model.AddressEmail) []dto.EmailResponse {
+	response := make([]dto.EmailResponse, 0, len(emails))
+
+	for _, e := range emails {
+		response = append(response, dto.EmailResponse{
+			Type:      e.Type,
+			Label:     e.Label,
+			Email:     e.Email,
+			IsPrimary: e.IsPrimary,
+		})
+	}
+
+	return response
+}
+
+func ToPhoneResponses(phones []model.AddressPhone) []dto.PhoneResponse {
+	response := make([]dto.PhoneResponse, 0, len(phones))
+
+	for _, p := range phones {
+		response = append(response, dto.PhoneResponse{
+			Type:      p.Type,
+			Label:     p.Label,
+			Phone:     p.Phone,
+			E164:      p.E164,
+			IsPrimary: p.IsPrimary,
+		})
+	}
+
+	return response
+}
+
+func ToPostalAddressResponses(postals []model.PostalAddress) []dto.PostalAddressResponse {
+	response := make([]dto.PostalAddressResponse, 0, len(postals))
+
+	for _, p := range postals {
+		response = append(response, dto.PostalAddressResponse{
+			Type:         p.Type,
+			Label:        p.Label,
+			AddressLine1: p.AddressLine1,
+			AddressLine2: p.AddressLine2,
+			City:         p.City,
+			State:        p.State,
+			Country:      p.Country,
+			Pincode:      p.Pincode,
+			CountryCode:  p.CountryCode,
+			StateCode:    p.StateCode,
+			PostalCode:   p.PostalCode,
+			IsPrimary:    p.IsPrimary,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"address-book-server/dto"
+	"address-book-server/model"
+
+	"strconv"
+)
+
+func ToCustomFieldResponse(field model.CustomField) dto.CustomFieldResponse {
+	options := field.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	return dto.CustomFieldResponse{
+		Id:       field.ID,
+		Key:      field.Key,
+		Name:     field.Name,
+		Type:     field.Type,
+		Required: field.Required,
+		Options:  options,
+	}
+}
+
+func ToCustomFieldMap(values []model.CustomFieldValue) map[string]interface{} {
+	fields := make(map[string]interface{}, len(values))
+
+	for _, v := range values {
+		if v.Field.Key == "" {
+			continue
+		}
+		fields[v.Field.Key] = typedCustomFieldValue(v.Field, v.Value)
+	}
+
+	return fields
+}
+
+// typedCustomFieldValue converts a stored value back into its JSON type.
+func typedCustomFieldValue(field model.CustomField, value string) interface{} {
+	switch field.Type {
+	case model.CustomFieldNumber:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case model.CustomFieldBoolean:
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
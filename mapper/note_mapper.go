@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"address-book-server/dto"
+	"address-book-server/model"
+
+	"fmt"
+)
+
+func ToNoteResponse(note model.Note) dto.NoteResponse {
+	return dto.NoteResponse{
+		Id:        note.ID,
+		AddressId: note.AddressID,
+		Body:      note.Body,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+}
+
+func ToAttachmentResponse(attachment model.Attachment, bookId uint64) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		Id:          attachment.ID,
+		AddressId:   attachment.AddressID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		DownloadURL: fmt.Sprintf("/api/v1/books/%d/addresses/%d/attachments/%d", bookId, attachment.AddressID, attachment.ID),
+		CreatedAt:   attachment.CreatedAt,
+		UpdatedAt:   attachment.UpdatedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccessResolver interface {
+	Role(userId, ownerId uint64) (string, error)
+}
+
+type BookResolver interface {
+	BookOwner(bookId uint64) (uint64, error)
+	DefaultBook(ownerId uint64) (uint64, error)
+}
+
+// AddressBookAccess resolves whose address book the request acts on (the
+// organization from X-Organization-ID, else the owner_id query parameter,
+// defaulting to the caller) and rejects callers
+// whose role on it is below the required one. It sets "owner_id" and "role"
+// on the context and must run after AuthMiddleware.
+func AddressBookAccess(resolver AccessResolver, required string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ownerId, ok := queryOwnerID(ctx)
+		if !ok {
+			return
+		}
+
+		if !authorize(ctx, resolver, ownerId, required) {
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// AddressBookScope works like AddressBookAccess but also pins the request to
+// a single book. Routes with a :bookId parameter act on that book and its
+// owner; other routes fall back to the owner's default book. The book is
+// stored as "book_id" on the context.
+func AddressBookScope(books BookResolver, resolver AccessResolver, required string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var ownerId, bookId uint64
+
+		if raw := ctx.Param("bookId"); raw != "" {
+			parsed, err := strconv.ParseUint(raw, 10, 64)
+
+			if err != nil {
+				ctx.Error(appError.BadRequest(
+					"Invalid address book ID",
+					err,
+				))
+				ctx.Abort()
+				return
+			}
+
+			ownerId, err = books.BookOwner(parsed)
+			if err != nil {
+				ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+
+			bookId = parsed
+		} else {
+			var ok bool
+			if ownerId, ok = queryOwnerID(ctx); !ok {
+				return
+			}
+		}
+
+		if !authorize(ctx, resolver, ownerId, required) {
+			return
+		}
+
+		if bookId == 0 {
+			var err error
+			if bookId, err = books.DefaultBook(ownerId); err != nil {
+				ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+		}
+
+		ctx.Set("book_id", bookId)
+		ctx.Next()
+	}
+}
+
+func queryOwnerID(ctx *gin.Context) (uint64, bool) {
+	ownerId := ctx.GetUint64("user_id")
+
+	if _, ok := ctx.Get("org_id"); ok {
+		if ctx.Query("owner_id") != "" {
+			ctx.Error(appError.BadRequest(
+				"owner_id cannot be combined with the "+OrganizationHeader+" header",
+				nil,
+			))
+			ctx.Abort()
+			return 0, false
+		}
+
+		return ctx.GetUint64("org_account_id"), true
+	}
+
+	if raw := ctx.Query("owner_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+
+		if err != nil {
+			ctx.Error(appError.BadRequest(
+				"Invalid owner ID",
+				err,
+			))
+			ctx.Abort()
+			return 0, false
+		}
+
+		ownerId = parsed
+	}
+
+	return ownerId, true
+}
+
+func authorize(ctx *gin.Context, resolver AccessResolver, ownerId uint64, required string) bool {
+	var role string
+
+	if _, ok := ctx.Get("org_id"); ok && ownerId == ctx.GetUint64("org_account_id") {
+		role = model.OrgBookRole(ctx.GetString("org_role"))
+	} else {
+		var err error
+		if role, err = resolver.Role(ctx.GetUint64("user_id"), ownerId); err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return false
+		}
+	}
+
+	if !model.RoleAllows(role, required) {
+		ctx.Error(appError.Forbidden(
+			"This action requires the "+required+" role",
+			nil,
+		))
+		ctx.Abort()
+		return false
+	}
+
+	ctx.Set("owner_id", ownerId)
+	ctx.Set("role", role)
+	return true
+}
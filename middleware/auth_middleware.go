@@ -42,6 +42,11 @@ func AuthMiddleware() gin.HandlerFunc {
 		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 			ctx.Set("user_id", uint64(claims["user_id"].(float64)))
 			ctx.Set("email", string(claims["email"].(string)))
+
+			if !resolveOrganization(ctx) {
+				return
+			}
+
 			ctx.Next()
 			return
 		}
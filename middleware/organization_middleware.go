@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const OrganizationHeader = "X-Organization-ID"
+
+type OrganizationResolver interface {
+	Membership(orgId, userId uint64) (uint64, string, error)
+}
+
+var organizations OrganizationResolver
+
+// UseOrganizations registers how AuthMiddleware checks organization
+// membership. It is called once at startup.
+func UseOrganizations(resolver OrganizationResolver) {
+	organizations = resolver
+}
+
+// resolveOrganization handles the X-Organization-ID header. When present the
+// caller must belong to that organization; "org_id", "org_role" and
+// "org_account_id" are then set on the context.
+func resolveOrganization(ctx *gin.Context) bool {
+	raw := ctx.GetHeader(OrganizationHeader)
+	if raw == "" {
+		return true
+	}
+
+	orgId, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		ctx.Error(appError.BadRequest(
+			"Invalid "+OrganizationHeader+" header",
+			err,
+		))
+		ctx.Abort()
+		return false
+	}
+
+	if organizations == nil {
+		ctx.Error(appError.Internal(
+			"Organizations are not available",
+			errors.New("organization resolver not registered"),
+		))
+		ctx.Abort()
+		return false
+	}
+
+	accountId, role, err := organizations.Membership(orgId, ctx.GetUint64("user_id"))
+	if err != nil {
+		ctx.Error(err)
+		ctx.Abort()
+		return false
+	}
+
+	ctx.Set("org_id", orgId)
+	ctx.Set("org_role", role)
+	ctx.Set("org_account_id", accountId)
+	return true
+}
+
+// OrganizationRole requires an organization context with at least the given
+// member role.
+func OrganizationRole(required string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := ctx.Get("org_id"); !ok {
+			ctx.Error(appError.BadRequest(
+				"The "+OrganizationHeader+" header is required",
+				nil,
+			))
+			ctx.Abort()
+			return
+		}
+
+		if model.OrgRoleRank(ctx.GetString("org_role")) < model.OrgRoleRank(required) {
+			ctx.Error(appError.Forbidden(
+				"This action requires the organization "+required+" role",
+				nil,
+			))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
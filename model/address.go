@@ -2,15 +2,28 @@ package model
 
 import "time"
 
+const (
+	GeocodePending  = "pending"
+	GeocodeOK       = "ok"
+	GeocodeNotFound = "not_found"
+	GeocodeFailed   = "failed"
+	// GeocodeSkipped marks contacts without any postal data.
+	GeocodeSkipped = "skipped"
+)
+
 type Address struct {
 	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
 
 	UserID uint64 `gorm:"index;not null" json:"user_id"`
+	BookID uint64 `gorm:"index" json:"book_id"`
 
+	// The flat contact fields mirror the primary entry of each child
+	// collection so that sorting and exports can work off a single row.
 	FirstName string `gorm:"type:varchar(100);not null" json:"first_name"`
 	LastName  string `gorm:"type:varchar(100)" json:"last_name"`
 	Email     string `gorm:"type:varchar(255);index;not null" json:"email"`
 	Phone     string `gorm:"type:varchar(20)" json:"phone" validate:"omitempty,phone"`
+	PhoneE164 string `gorm:"type:varchar(16)" json:"phone_e164"`
 
 	AddressLine1 string `gorm:"type:varchar(255);not null" json:"address_line1"`
 	AddressLine2 string `gorm:"type:varchar(255)" json:"address_line2"`
@@ -19,7 +32,61 @@ type Address struct {
 	Country      string `gorm:"type:varchar(100)" json:"country"`
 	Pincode      string `gorm:"type:varchar(20)" json:"pincode"`
 
+	// CountryCode, StateCode and PostalCode are the normalized forms of
+	// Country, State and Pincode: ISO 3166-1 alpha-2, ISO 3166-2 and the
+	// country's postal code format. They are empty when the input could not
+	// be resolved. PostalVersion records which revision produced them.
+	CountryCode   string `gorm:"type:varchar(2);index" json:"country_code"`
+	StateCode     string `gorm:"type:varchar(10);index" json:"state_code"`
+	PostalCode    string `gorm:"type:varchar(20)" json:"postal_code"`
+	PostalVersion string `gorm:"type:varchar(20);index" json:"-"`
+
+	// NameKey is the accent and case folded full name used by substring
+	// search; SearchKeyVersion records which normalization produced it.
+	NameKey          string `gorm:"type:varchar(255)" json:"-"`
+	SearchKeyVersion string `gorm:"type:varchar(20);index" json:"-"`
+
+	// Phonetic codes of the first and last name for sound-alike search.
+	FirstNameMetaphone    string `gorm:"type:varchar(10);index" json:"-"`
+	FirstNameMetaphoneAlt string `gorm:"type:varchar(10);index" json:"-"`
+	FirstNameSoundex      string `gorm:"type:varchar(10);index" json:"-"`
+	LastNameMetaphone     string `gorm:"type:varchar(10);index" json:"-"`
+	LastNameMetaphoneAlt  string `gorm:"type:varchar(10);index" json:"-"`
+	LastNameSoundex       string `gorm:"type:varchar(10);index" json:"-"`
+
+	// Latitude and Longitude are filled in the background by the geocoder;
+	// GeocodeStatus tracks that work and GeocodeAccuracy how precise the
+	// point is. The composite index serves bounding-box prefilters.
+	Latitude        *float64   `gorm:"index:idx_addresses_location,priority:1" json:"latitude"`
+	Longitude       *float64   `gorm:"index:idx_addresses_location,priority:2" json:"longitude"`
+	GeocodeStatus   string     `gorm:"type:varchar(20);index;default:'pending'" json:"geocode_status"`
+	GeocodeAccuracy string     `gorm:"type:varchar(20)" json:"geocode_accuracy"`
+	GeocodedAt      *time.Time `json:"geocoded_at"`
+
+	PhotoKey         string `gorm:"type:varchar(255)" json:"-"`
+	ThumbnailKey     string `gorm:"type:varchar(255)" json:"-"`
+	PhotoContentType string `gorm:"type:varchar(50)" json:"-"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	IsDeleted bool      `gorm:"default:false" json:"is_deleted"`
+
+	// SearchScore and SearchHighlight are only filled by ranked searches,
+	// which select them alongside the row.
+	SearchScore     float64 `gorm:"->;-:migration" json:"-"`
+	SearchHighlight string  `gorm:"->;-:migration" json:"-"`
+
+	// DistanceKm is only filled by searches near a point.
+	DistanceKm float64 `gorm:"->;-:migration" json:"-"`
+
+	Emails          []AddressEmail  `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"emails,omitempty"`
+	Phones          []AddressPhone  `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"phones,omitempty"`
+	PostalAddresses []PostalAddress `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"postal_addresses,omitempty"`
+
+	Events []ContactEvent `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"events,omitempty"`
+
+	CustomFieldValues []CustomFieldValue `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"custom_field_values,omitempty"`
+
+	Tags   []Tag          `gorm:"many2many:address_tags;constraint:OnDelete:CASCADE;" json:"tags,omitempty"`
+	Groups []ContactGroup `gorm:"many2many:address_groups;constraint:OnDelete:CASCADE;" json:"groups,omitempty"`
 }
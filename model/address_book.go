@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+const DefaultAddressBookName = "Personal"
+
+type AddressBook struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	UserID uint64 `gorm:"not null;uniqueIndex:idx_address_books_user_name" json:"user_id"`
+
+	Name      string `gorm:"type:varchar(100);not null;uniqueIndex:idx_address_books_user_name" json:"name"`
+	IsDefault bool   `gorm:"not null;default:false" json:"is_default"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+const (
+	RoleViewer  = "viewer"
+	RoleEditor  = "editor"
+	RoleManager = "manager"
+	RoleOwner   = "owner"
+)
+
+const (
+	ShareStatusPending  = "pending"
+	ShareStatusAccepted = "accepted"
+	ShareStatusRevoked  = "revoked"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer:  1,
+	RoleEditor:  2,
+	RoleManager: 3,
+	RoleOwner:   4,
+}
+
+// RoleAllows reports whether role grants at least the required role.
+func RoleAllows(role, required string) bool {
+	return roleRanks[role] >= roleRanks[required] && roleRanks[role] > 0
+}
+
+// AddressBookShare grants another user access to an owner's contacts. It
+// starts as an emailed invitation and is bound to the grantee on acceptance.
+type AddressBookShare struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	OwnerID      uint64  `gorm:"index;not null" json:"owner_id"`
+	GranteeID    *uint64 `gorm:"index" json:"grantee_id"`
+	InviteeEmail string  `gorm:"type:varchar(255);index;not null" json:"invitee_email"`
+	InvitedBy    uint64  `gorm:"not null" json:"invited_by"`
+
+	Role   string `gorm:"type:varchar(20);not null" json:"role"`
+	Status string `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+
+	TokenHash  string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Owner User `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE;" json:"-"`
+}
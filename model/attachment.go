@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type Attachment struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"index;not null" json:"address_id"`
+	UserID    uint64 `gorm:"index;not null" json:"user_id"`
+
+	FileName    string `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType string `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64  `gorm:"not null" json:"size"`
+	BlobKey     string `gorm:"type:varchar(255);not null" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	IsDeleted bool      `gorm:"default:false" json:"is_deleted"`
+
+	Address Address `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"-"`
+}
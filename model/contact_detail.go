@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+type AddressEmail struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"index;not null" json:"address_id"`
+
+	Type      string `gorm:"type:varchar(20);not null;default:'other'" json:"type"`
+	Label     string `gorm:"type:varchar(50)" json:"label"`
+	Email     string `gorm:"type:varchar(255);index;not null" json:"email"`
+	IsPrimary bool   `gorm:"default:false" json:"is_primary"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AddressPhone struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"index;not null" json:"address_id"`
+
+	Type      string `gorm:"type:varchar(20);not null;default:'other'" json:"type"`
+	Label     string `gorm:"type:varchar(50)" json:"label"`
+	Phone     string `gorm:"type:varchar(20);not null" json:"phone"`
+	IsPrimary bool   `gorm:"default:false" json:"is_primary"`
+
+	// E164 is Phone parsed with the contact's country as region hint, empty
+	// when it could not be. Digits holds its digits, or those of Phone, for
+	// matching however the number was typed.
+	E164   string `gorm:"type:varchar(16);index" json:"e164"`
+	Digits string `gorm:"type:varchar(20)" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PostalAddress struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"index;not null" json:"address_id"`
+
+	Type      string `gorm:"type:varchar(20);not null;default:'other'" json:"type"`
+	Label     string `gorm:"type:varchar(50)" json:"label"`
+	IsPrimary bool   `gorm:"default:false" json:"is_primary"`
+
+	AddressLine1 string `gorm:"type:varchar(255);not null" json:"address_line1"`
+	AddressLine2 string `gorm:"type:varchar(255)" json:"address_line2"`
+	City         string `gorm:"type:varchar(100);index" json:"city"`
+	State        string `gorm:"type:varchar(100)" json:"state"`
+	Country      string `gorm:"type:varchar(100);index" json:"country"`
+	Pincode      string `gorm:"type:varchar(20)" json:"pincode"`
+
+	// CountryCode, StateCode and PostalCode are normalized like their
+	// namesakes on Address.
+	CountryCode string `gorm:"type:varchar(2);index" json:"country_code"`
+	StateCode   string `gorm:"type:varchar(10)" json:"state_code"`
+	PostalCode  string `gorm:"type:varchar(20)" json:"postal_code"`
+
+	// CityKey and CountryKey are the normalized forms the list filters match.
+	CityKey    string `gorm:"type:varchar(100);index" json:"-"`
+	CountryKey string `gorm:"type:varchar(100);index" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	EventBirthday    = "birthday"
+	EventAnniversary = "anniversary"
+	EventCustom      = "custom"
+)
+
+// ContactEvent is a yearly recurring date on a contact. Year is optional so
+// that birthdays can be stored without disclosing the year of birth.
+type ContactEvent struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"index;not null" json:"address_id"`
+
+	Type  string `gorm:"type:varchar(20);not null" json:"type"`
+	Label string `gorm:"type:varchar(100)" json:"label"`
+	Month int    `gorm:"not null;index:idx_contact_events_month_day" json:"month"`
+	Day   int    `gorm:"not null;index:idx_contact_events_month_day" json:"day"`
+	Year  *int   `json:"year"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type ContactGroup struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	UserID   uint64  `gorm:"index;not null" json:"user_id"`
+	ParentID *uint64 `gorm:"index" json:"parent_id"`
+
+	Name string `gorm:"type:varchar(100);not null" json:"name"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Parent    *ContactGroup `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL;" json:"-"`
+	Addresses []Address     `gorm:"many2many:address_groups;constraint:OnDelete:CASCADE;" json:"-"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+const (
+	CustomFieldString  = "string"
+	CustomFieldNumber  = "number"
+	CustomFieldDate    = "date"
+	CustomFieldBoolean = "boolean"
+	CustomFieldEnum    = "enum"
+)
+
+type CustomField struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	UserID uint64 `gorm:"not null;uniqueIndex:idx_custom_fields_user_key" json:"user_id"`
+
+	Key      string   `gorm:"type:varchar(50);not null;uniqueIndex:idx_custom_fields_user_key" json:"key"`
+	Name     string   `gorm:"type:varchar(100);not null" json:"name"`
+	Type     string   `gorm:"type:varchar(20);not null" json:"type"`
+	Required bool     `gorm:"default:false" json:"required"`
+	Options  []string `gorm:"serializer:json" json:"options"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CustomFieldValue struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"not null;uniqueIndex:idx_custom_field_values_address_field" json:"address_id"`
+	FieldID   uint64 `gorm:"not null;index;uniqueIndex:idx_custom_field_values_address_field" json:"field_id"`
+
+	Value string `gorm:"type:text;not null" json:"value"`
+
+	Field CustomField `gorm:"foreignKey:FieldID;constraint:OnDelete:CASCADE;" json:"-"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type Note struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	AddressID uint64 `gorm:"index;not null" json:"address_id"`
+	UserID    uint64 `gorm:"index;not null" json:"user_id"`
+
+	Body string `gorm:"type:text;not null" json:"body"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	IsDeleted bool      `gorm:"default:false" json:"is_deleted"`
+
+	Address Address `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE;" json:"-"`
+}
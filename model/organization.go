@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+const (
+	OrgRoleMember = "member"
+	OrgRoleAdmin  = "admin"
+	OrgRoleOwner  = "owner"
+)
+
+var orgRoleRanks = map[string]int{
+	OrgRoleMember: 1,
+	OrgRoleAdmin:  2,
+	OrgRoleOwner:  3,
+}
+
+// OrgRoleRank orders organization roles; unknown roles rank 0.
+func OrgRoleRank(role string) int {
+	return orgRoleRanks[role]
+}
+
+// OrgBookRole maps an organization role onto the access it grants to the
+// organization's address books: everyone can read the directory, admins
+// maintain it.
+func OrgBookRole(role string) string {
+	switch role {
+	case OrgRoleOwner:
+		return RoleOwner
+	case OrgRoleAdmin:
+		return RoleManager
+	case OrgRoleMember:
+		return RoleViewer
+	}
+	return ""
+}
+
+// Organization owns its address books through AccountID, a user row that
+// cannot sign in. Keeping org data under a user ID lets every user-scoped
+// table and query serve organizations unchanged.
+type Organization struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	Name      string `gorm:"type:varchar(100);not null" json:"name"`
+	AccountID uint64 `gorm:"uniqueIndex;not null" json:"-"`
+	CreatedBy uint64 `gorm:"not null" json:"created_by"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Account User `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type OrganizationMember struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	OrganizationID uint64 `gorm:"not null;uniqueIndex:idx_organization_members_org_user" json:"organization_id"`
+	UserID         uint64 `gorm:"not null;uniqueIndex:idx_organization_members_org_user;index" json:"user_id"`
+
+	Role string `gorm:"type:varchar(20);not null" json:"role"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	User         User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// OrganizationInvite uses the same pending/accepted/revoked lifecycle as
+// address book shares.
+type OrganizationInvite struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	OrganizationID uint64 `gorm:"index;not null" json:"organization_id"`
+	Email          string `gorm:"type:varchar(255);index;not null" json:"email"`
+	InvitedBy      uint64 `gorm:"not null" json:"invited_by"`
+
+	Role   string `gorm:"type:varchar(20);not null" json:"role"`
+	Status string `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+
+	TokenHash  string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
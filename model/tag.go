@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Tag names are unique per user regardless of case, through the
+// idx_tags_user_lower_name expression index created in utils.
+type Tag struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	UserID uint64 `gorm:"not null" json:"user_id"`
+
+	Name  string `gorm:"type:varchar(50);not null" json:"name"`
+	Color string `gorm:"type:varchar(20)" json:"color"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
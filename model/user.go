@@ -8,6 +8,10 @@ type User struct {
 	Email        string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
 	PasswordHash string `gorm:"type:varchar(255);not null" json:"-"`
 
+	// CalendarTokenHash is the SHA-256 of the secret in the user's iCalendar
+	// feed URL; the plain token is only shown once when it is generated.
+	CalendarTokenHash *string `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
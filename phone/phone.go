@@ -0,0 +1,206 @@
+// Package phone parses phone numbers as people type them into E.164, the
+// "+<country code><number>" form used for storage and matching. National
+// numbers need a region hint; numbers with a "+" or an international
+// prefix carry their own country.
+package phone
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//go:embed data/calling_codes.csv
+var callingCodesCSV []byte
+
+// MaxDigits is the longest number E.164 allows, country code included.
+const MaxDigits = 15
+
+var (
+	// ErrInvalid means the input is not shaped like a phone number at all.
+	ErrInvalid = errors.New("not a phone number")
+	// ErrCallingCode means an international number starts with no known
+	// country calling code.
+	ErrCallingCode = errors.New("unknown country calling code")
+	// ErrNoRegion means a national number was given without a usable
+	// region hint, so its country is unknown.
+	ErrNoRegion = errors.New("no region to interpret a national number")
+	// ErrNumberPlan means the number breaks the numbering rules of its
+	// country; Number says which.
+	ErrNumberPlan = errors.New("not a valid number for its country")
+)
+
+// Number is a parsed phone number. Region is the hint a national number
+// was read with and is empty for international input, since a calling code
+// can be shared by several regions.
+type Number struct {
+	E164        string
+	CallingCode string
+	Region      string
+}
+
+type region struct {
+	callingCode string
+	trunkPrefix string
+}
+
+var (
+	regions      = map[string]region{}
+	callingCodes = map[string]bool{}
+)
+
+func init() {
+	records, err := csv.NewReader(bytes.NewReader(callingCodesCSV)).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+
+	for i, record := range records[1:] {
+		if len(record) != 3 {
+			panic(fmt.Sprintf("calling codes line %d: expected 3 columns, got %d", i+2, len(record)))
+		}
+
+		regions[record[0]] = region{callingCode: record[1], trunkPrefix: record[2]}
+		callingCodes[record[1]] = true
+	}
+}
+
+// separators may appear between the digits of a typed number.
+var separators = regexp.MustCompile(`^\+?[0-9 ().\-/]+$`)
+
+// Digits returns the ASCII digits of s in order.
+func Digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SearchDigits turns a search term shaped like a whole or partial phone
+// number into the digits to look for within stored digits. Leading zeros,
+// the usual international and trunk prefixes, are dropped, so "098765"
+// finds +919876543210. Other terms, and those with fewer than 3 digits,
+// give "".
+func SearchDigits(s string) string {
+	s = strings.TrimSpace(s)
+	if !separators.MatchString(s) {
+		return ""
+	}
+
+	digits := strings.TrimLeft(Digits(s), "0")
+	if len(digits) < 3 {
+		return ""
+	}
+	return digits
+}
+
+// Plausible reports whether s looks like a phone number in any country:
+// digits with the usual separators, an optional leading "+", and between 5
+// and MaxDigits digits once an international prefix is dropped. It needs no
+// region, so it suits request validation; Parse applies the country rules.
+func Plausible(s string) bool {
+	s = strings.TrimSpace(s)
+	if !separators.MatchString(s) {
+		return false
+	}
+
+	digits := Digits(s)
+	if !strings.HasPrefix(s, "+") {
+		digits = strings.TrimPrefix(digits, "00")
+	}
+
+	return len(digits) >= 5 && len(digits) <= MaxDigits
+}
+
+// Parse reads s, as typed, into E.164. The region, an ISO 3166-1 alpha-2
+// code, is used for national numbers, which may include the country's
+// trunk prefix, such as the leading 0 of "098765 43210".
+func Parse(s, regionCode string) (Number, error) {
+	s = strings.TrimSpace(s)
+	if !separators.MatchString(s) {
+		return Number{}, ErrInvalid
+	}
+
+	digits := Digits(s)
+	hint, hinted := regions[strings.ToUpper(regionCode)]
+
+	switch {
+	case strings.HasPrefix(s, "+"):
+		return parseInternational(digits)
+	case strings.HasPrefix(digits, "00"):
+		return parseInternational(digits[2:])
+	case hinted && hint.callingCode == "1" && strings.HasPrefix(digits, "011"):
+		// The North American international prefix.
+		return parseInternational(digits[3:])
+	}
+
+	if !hinted {
+		return Number{}, ErrNoRegion
+	}
+
+	code := hint.callingCode
+	number := Number{CallingCode: code, Region: strings.ToUpper(regionCode)}
+
+	// The number as typed, without its trunk prefix, or with the country
+	// code but no "+", in that order.
+	candidates := []string{digits}
+	if hint.trunkPrefix != "" && strings.HasPrefix(digits, hint.trunkPrefix) {
+		candidates = append(candidates, digits[len(hint.trunkPrefix):])
+	}
+	if strings.HasPrefix(digits, code) {
+		candidates = append(candidates, digits[len(code):])
+	}
+
+	for _, national := range candidates {
+		if validNational(code, national) {
+			number.E164 = "+" + code + national
+			return number, nil
+		}
+	}
+
+	return number, ErrNumberPlan
+}
+
+func parseInternational(digits string) (Number, error) {
+	if len(digits) > MaxDigits {
+		return Number{}, ErrInvalid
+	}
+
+	// Calling codes are prefix-free, so the first match is the only one.
+	for length := 1; length <= 3 && length < len(digits); length++ {
+		code := digits[:length]
+		if !callingCodes[code] {
+			continue
+		}
+
+		national := digits[length:]
+		number := Number{CallingCode: code}
+		if !validNational(code, national) {
+			return number, ErrNumberPlan
+		}
+
+		number.E164 = "+" + digits
+		return number, nil
+	}
+
+	return Number{}, ErrCallingCode
+}
+
+// validNational checks a national significant number against the plan of
+// its calling code, or only its length where there is no plan.
+func validNational(code, national string) bool {
+	if len(code)+len(national) > MaxDigits {
+		return false
+	}
+	if plan, ok := plans[code]; ok {
+		return plan.MatchString(national)
+	}
+	return len(national) >= 4
+}
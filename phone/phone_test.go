@@ -0,0 +1,93 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		region string
+		want   Number
+		err    error
+	}{
+		// National numbers, with or without the trunk prefix or country code.
+		{"098765 43210", "IN", Number{"+919876543210", "91", "IN"}, nil},
+		{"9876543210", "in", Number{"+919876543210", "91", "IN"}, nil},
+		{"919876543210", "IN", Number{"+919876543210", "91", "IN"}, nil},
+		{"(415) 555-2671", "US", Number{"+14155552671", "1", "US"}, nil},
+		{"1 415 555 2671", "US", Number{"+14155552671", "1", "US"}, nil},
+		{"020 7946 0958", "GB", Number{"+442079460958", "44", "GB"}, nil},
+		{"030 901820", "DE", Number{"+4930901820", "49", "DE"}, nil},
+		{"06 69821234", "IT", Number{"+390669821234", "39", "IT"}, nil},
+
+		// International numbers ignore the region.
+		{"+91 98765 43210", "", Number{"+919876543210", "91", ""}, nil},
+		{"0091 9876543210", "US", Number{"+919876543210", "91", ""}, nil},
+		{"011 44 20 7946 0958", "US", Number{"+442079460958", "44", ""}, nil},
+		{"+1 (212) 555-0199", "GB", Number{"+12125550199", "1", ""}, nil},
+		{"+65 6123 4567", "", Number{"+6561234567", "65", ""}, nil},
+		{"+971 50 123 4567", "", Number{"+971501234567", "971", ""}, nil},
+
+		{"+7 123 456 7890", "", Number{CallingCode: "7"}, ErrNumberPlan},
+		{"555-0100", "US", Number{CallingCode: "1", Region: "US"}, ErrNumberPlan},
+		{"+999 1234567", "", Number{}, ErrCallingCode},
+		{"12345", "", Number{}, ErrNoRegion},
+		{"98765 43210", "XX", Number{}, ErrNoRegion},
+		{"call me", "IN", Number{}, ErrInvalid},
+		{"+1234567890123456", "", Number{}, ErrInvalid},
+		{"", "IN", Number{}, ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input, tt.region)
+		if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+			t.Errorf("Parse(%q, %q) error = %v, want %v", tt.input, tt.region, err, tt.err)
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q, %q) = %+v, want %+v", tt.input, tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestPlausible(t *testing.T) {
+	tests := map[string]bool{
+		"+91 98765 43210":    true,
+		"(415) 555-2671":     true,
+		"0044 20 7946 0958":  true,
+		"12345":              true,
+		"1234":               false,
+		"00 1234":            false,
+		"+1234567890123456":  false,
+		"123456789012345678": false,
+		"call me":            false,
+		"98765 43210 ext 5":  false,
+		"":                   false,
+	}
+
+	for input, want := range tests {
+		if got := Plausible(input); got != want {
+			t.Errorf("Plausible(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestSearchDigits(t *testing.T) {
+	tests := map[string]string{
+		"098765":         "98765",
+		"+91 98765":      "9198765",
+		"(415) 555":      "415555",
+		"12":             "",
+		"000":            "",
+		"john":           "",
+		"98765 john":     "",
+		" 0044 20 7946 ": "44207946",
+	}
+
+	for input, want := range tests {
+		if got := SearchDigits(input); got != want {
+			t.Errorf("SearchDigits(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package phone
+
+import "regexp"
+
+// plans holds the national significant number pattern, without trunk
+// prefix, of the calling codes we know well. Other codes are only checked
+// for length.
+var plans = map[string]*regexp.Regexp{
+	// North American Numbering Plan: area code and exchange never start
+	// with 0 or 1.
+	"1":  regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`),
+	"7":  regexp.MustCompile(`^[3-9]\d{9}$`),
+	"31": regexp.MustCompile(`^[1-9]\d{8}$`),
+	"33": regexp.MustCompile(`^[1-9]\d{8}$`),
+	"34": regexp.MustCompile(`^[5-9]\d{8}$`),
+	// Italian landlines keep their leading 0 after the country code.
+	"39": regexp.MustCompile(`^(0\d{5,10}|3\d{8,9})$`),
+	"44": regexp.MustCompile(`^[1-9]\d{8,9}$`),
+	"49": regexp.MustCompile(`^[1-9]\d{5,12}$`),
+	"55": regexp.MustCompile(`^[1-9]\d(9\d{8}|[2-5]\d{7})$`),
+	"61": regexp.MustCompile(`^[1-9]\d{8}$`),
+	"65": regexp.MustCompile(`^[3689]\d{7}$`),
+	"81": regexp.MustCompile(`^[1-9]\d{8,9}$`),
+	// Indian mobiles start with 6 to 9, landlines with their STD code.
+	"91":  regexp.MustCompile(`^[1-9]\d{9}$`),
+	"971": regexp.MustCompile(`^[1-9]\d{7,8}$`),
+}
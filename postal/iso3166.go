@@ -0,0 +1,157 @@
+// Package postal normalizes postal addresses: countries to ISO 3166-1
+// alpha-2 codes, states to ISO 3166-2 subdivisions and postal codes to the
+// format each country prints them in. The reference tables are embedded.
+package postal
+
+import (
+	"address-book-server/utils"
+
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//go:embed data/countries.csv
+var countriesCSV []byte
+
+//go:embed data/subdivisions.csv
+var subdivisionsCSV []byte
+
+// Country is an ISO 3166-1 entry.
+type Country struct {
+	Alpha2 string
+	Alpha3 string
+	Name   string
+}
+
+// Subdivision is an ISO 3166-2 entry. Code carries the country prefix, as
+// in "IN-MH".
+type Subdivision struct {
+	Code string
+	Name string
+}
+
+var (
+	countries         = map[string]Country{}
+	countriesByKey    = map[string]Country{}
+	subdivisionsByKey = map[string]map[string]Subdivision{}
+)
+
+func init() {
+	if err := loadCountries(); err != nil {
+		panic(err)
+	}
+	if err := loadSubdivisions(); err != nil {
+		panic(err)
+	}
+	// Postal codes refer to subdivisions, so they load last.
+	if err := loadPostcodes(); err != nil {
+		panic(err)
+	}
+}
+
+func loadCountries() error {
+	records, err := csv.NewReader(bytes.NewReader(countriesCSV)).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	for i, record := range records[1:] {
+		if len(record) != 4 {
+			return fmt.Errorf("countries line %d: expected 4 columns, got %d", i+2, len(record))
+		}
+
+		country := Country{Alpha2: record[0], Alpha3: record[1], Name: record[2]}
+		countries[country.Alpha2] = country
+
+		for _, name := range append([]string{record[0], record[1], record[2]}, aliases(record[3])...) {
+			countriesByKey[lookupKey(name)] = country
+		}
+	}
+
+	return nil
+}
+
+func loadSubdivisions() error {
+	records, err := csv.NewReader(bytes.NewReader(subdivisionsCSV)).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	for i, record := range records[1:] {
+		if len(record) != 3 {
+			return fmt.Errorf("subdivisions line %d: expected 3 columns, got %d", i+2, len(record))
+		}
+
+		country, local, ok := strings.Cut(record[0], "-")
+		if !ok {
+			return fmt.Errorf("subdivisions line %d: invalid code %q", i+2, record[0])
+		}
+		if _, known := countries[country]; !known {
+			return fmt.Errorf("subdivisions line %d: unknown country %q", i+2, country)
+		}
+
+		if subdivisionsByKey[country] == nil {
+			subdivisionsByKey[country] = map[string]Subdivision{}
+		}
+
+		subdivision := Subdivision{Code: record[0], Name: record[1]}
+		for _, name := range append([]string{record[0], local, record[1]}, aliases(record[2])...) {
+			subdivisionsByKey[country][lookupKey(name)] = subdivision
+		}
+	}
+
+	return nil
+}
+
+func aliases(field string) []string {
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, "|")
+}
+
+// lookupKey folds accents and case like the search keys and drops
+// punctuation, so "U.S.A." matches "usa" and "Côte d'Ivoire" "cote divoire".
+func lookupKey(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' {
+			return ' '
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return r
+		}
+		return -1
+	}, s)
+
+	return utils.SearchKey(s)
+}
+
+// LookupCountry finds a country by alpha-2 or alpha-3 code, English name
+// or a common alias.
+func LookupCountry(s string) (Country, bool) {
+	country, ok := countriesByKey[lookupKey(s)]
+	return country, ok
+}
+
+// CountryByCode returns the country with the given alpha-2 code.
+func CountryByCode(alpha2 string) (Country, bool) {
+	country, ok := countries[strings.ToUpper(alpha2)]
+	return country, ok
+}
+
+// LookupSubdivision finds a subdivision of the country with the given
+// alpha-2 code by its full or local code, name or alias. Only the
+// countries in the embedded table have subdivisions.
+func LookupSubdivision(country, s string) (Subdivision, bool) {
+	subdivision, ok := subdivisionsByKey[strings.ToUpper(country)][lookupKey(s)]
+	return subdivision, ok
+}
+
+// HasSubdivisions reports whether subdivisions of the country are known.
+func HasSubdivisions(country string) bool {
+	return subdivisionsByKey[strings.ToUpper(country)] != nil
+}
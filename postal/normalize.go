@@ -0,0 +1,49 @@
+package postal
+
+import "address-book-server/model"
+
+// Revision changes whenever the tables or rules here do, so that the
+// backfill picks up contacts normalized under an older revision.
+const Revision = "2"
+
+// Normalized holds the standardized form of a postal address. Fields that
+// could not be resolved are empty; the input is never rewritten.
+type Normalized struct {
+	CountryCode string
+	StateCode   string
+	PostalCode  string
+}
+
+// Normalize resolves the country, the state within it and the postal code.
+// Postal codes are still tidied when the country is unknown.
+func Normalize(country, state, pincode string) Normalized {
+	var n Normalized
+
+	if c, ok := LookupCountry(country); ok {
+		n.CountryCode = c.Alpha2
+	}
+	if s, ok := LookupSubdivision(n.CountryCode, state); ok && state != "" {
+		n.StateCode = s.Code
+	}
+	n.PostalCode = NormalizePostalCode(n.CountryCode, pincode)
+
+	return n
+}
+
+// ApplyCodes fills the normalized postal columns of a contact and of each
+// of its postal addresses from their display values.
+func ApplyCodes(address *model.Address) {
+	n := Normalize(address.Country, address.State, address.Pincode)
+	address.CountryCode = n.CountryCode
+	address.StateCode = n.StateCode
+	address.PostalCode = n.PostalCode
+	address.PostalVersion = Revision
+
+	for i := range address.PostalAddresses {
+		p := &address.PostalAddresses[i]
+		n := Normalize(p.Country, p.State, p.Pincode)
+		p.CountryCode = n.CountryCode
+		p.StateCode = n.StateCode
+		p.PostalCode = n.PostalCode
+	}
+}
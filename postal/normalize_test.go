@@ -0,0 +1,97 @@
+package postal
+
+import (
+	"address-book-server/model"
+
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		country, state, pincode string
+		want                    Normalized
+	}{
+		{"India", "Maharashtra", "400 001", Normalized{"IN", "IN-MH", "400001"}},
+		{"IN", "MH", "400001", Normalized{"IN", "IN-MH", "400001"}},
+		{"Bharat", "maharashtra", "400-001", Normalized{"IN", "IN-MH", "400001"}},
+		{"United States", "California", "94105-1234", Normalized{"US", "US-CA", "94105-1234"}},
+		{"USA", "CA", "941051234", Normalized{"US", "US-CA", "94105-1234"}},
+		{"us", "New York", "10001", Normalized{"US", "US-NY", "10001"}},
+		{"Canada", "Ontario", "k1a0b1", Normalized{"CA", "CA-ON", "K1A 0B1"}},
+		{"United Kingdom", "England", "sw1a1aa", Normalized{"GB", "GB-ENG", "SW1A 1AA"}},
+		{"UK", "", "ec1a 1bb", Normalized{"GB", "", "EC1A 1BB"}},
+		{"Deutschland", "Bayern", "80331", Normalized{"DE", "DE-BY", "80331"}},
+		{"Australia", "New South Wales", "2000", Normalized{"AU", "AU-NSW", "2000"}},
+		{"Netherlands", "", "1012ab", Normalized{"NL", "", "1012 AB"}},
+		{"Japan", "", "1000001", Normalized{"JP", "", "100-0001"}},
+		{"Brazil", "", "01310100", Normalized{"BR", "", "01310-100"}},
+		{"Côte d'Ivoire", "", "", Normalized{"CI", "", ""}},
+		{"south korea", "", "03187", Normalized{"KR", "", "03187"}},
+		{"India", "Andaman & Nicobar Islands", "744101", Normalized{"IN", "IN-AN", "744101"}},
+		// Subdivisions are only resolved for countries with a table.
+		{"France", "Île-de-France", "75001", Normalized{"FR", "", "75001"}},
+		// Codes that do not fit the country are tidied, not rejected.
+		{"Germany", "BY", "8033", Normalized{"DE", "DE-BY", "8033"}},
+		{"India", "Narnia", "4000011", Normalized{"IN", "", "4000011"}},
+		{"Atlantis", "Nowhere", " ab-12 ", Normalized{"", "", "AB-12"}},
+		{"", "", "", Normalized{}},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.country, tt.state, tt.pincode); got != tt.want {
+			t.Errorf("Normalize(%q, %q, %q) = %+v, want %+v", tt.country, tt.state, tt.pincode, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		country, code, want string
+	}{
+		{"IN", " 560 034 ", "560034"},
+		{"in", "560034", "560034"},
+		{"US", "02115", "02115"},
+		{"US", "02115 0001", "02115-0001"},
+		{"CA", "m5v-3l9", "M5V 3L9"},
+		{"GB", "w1a0ax", "W1A 0AX"},
+		{"IE", "d02x285", "D02 X285"},
+		{"PL", "00950", "00-950"},
+		{"SE", "11455", "114 55"},
+		{"PT", "1100148", "1100-148"},
+		{"US", "ABCDE", "ABCDE"},
+		{"", "  sw1a   1aa ", "SW1A 1AA"},
+		{"IN", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePostalCode(tt.country, tt.code); got != tt.want {
+			t.Errorf("NormalizePostalCode(%q, %q) = %q, want %q", tt.country, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestApplyCodes(t *testing.T) {
+	address := model.Address{
+		Country: "India",
+		State:   "Karnataka",
+		Pincode: "560 034",
+		PostalAddresses: []model.PostalAddress{
+			{Country: "India", State: "Karnataka", Pincode: "560 034"},
+			{Country: "USA", State: "NY", Pincode: "10001"},
+		},
+	}
+
+	ApplyCodes(&address)
+
+	if address.CountryCode != "IN" || address.StateCode != "IN-KA" || address.PostalCode != "560034" || address.PostalVersion != Revision {
+		t.Errorf("contact codes = %q, %q, %q, %q", address.CountryCode, address.StateCode, address.PostalCode, address.PostalVersion)
+	}
+
+	work := address.PostalAddresses[1]
+	if work.CountryCode != "US" || work.StateCode != "US-NY" || work.PostalCode != "10001" {
+		t.Errorf("postal address codes = %q, %q, %q", work.CountryCode, work.StateCode, work.PostalCode)
+	}
+	if address.Country != "India" || address.Pincode != "560 034" {
+		t.Error("display values were rewritten")
+	}
+}
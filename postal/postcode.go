@@ -0,0 +1,109 @@
+package postal
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// postcodeFormat rewrites a postal code, with separators and case already
+// stripped, into the country's printed form. It reports false when the code
+// does not have the country's shape, in which case it is left alone.
+type postcodeFormat func(compact string) (string, bool)
+
+var (
+	digits5  = regexp.MustCompile(`^\d{5}$`)
+	digits6  = regexp.MustCompile(`^\d{6}$`)
+	digits7  = regexp.MustCompile(`^\d{7}$`)
+	digits8  = regexp.MustCompile(`^\d{8}$`)
+	digits4  = regexp.MustCompile(`^\d{4}$`)
+	usZip    = regexp.MustCompile(`^\d{5}(\d{4})?$`)
+	caPostal = regexp.MustCompile(`^[A-Z]\d[A-Z]\d[A-Z]\d$`)
+	gbPostal = regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]?\d[A-Z]{2}$`)
+	nlPostal = regexp.MustCompile(`^\d{4}[A-Z]{2}$`)
+	iePostal = regexp.MustCompile(`^[A-Z]\d[\dW][A-Z\d]{4}$`)
+)
+
+var postcodeFormats = map[string]postcodeFormat{
+	"US": func(c string) (string, bool) {
+		if !usZip.MatchString(c) {
+			return "", false
+		}
+		if len(c) == 9 {
+			return c[:5] + "-" + c[5:], true
+		}
+		return c, true
+	},
+	"IN": matching(digits6),
+	"CA": split(caPostal, 3, " "),
+	"GB": func(c string) (string, bool) {
+		if !gbPostal.MatchString(c) {
+			return "", false
+		}
+		return c[:len(c)-3] + " " + c[len(c)-3:], true
+	},
+	"NL": split(nlPostal, 4, " "),
+	"IE": split(iePostal, 3, " "),
+	"BR": split(digits8, 5, "-"),
+	"JP": split(digits7, 3, "-"),
+	"PL": split(digits5, 2, "-"),
+	"SE": split(digits5, 3, " "),
+	"PT": split(digits7, 4, "-"),
+	"DE": matching(digits5),
+	"FR": matching(digits5),
+	"IT": matching(digits5),
+	"ES": matching(digits5),
+	"AU": matching(digits4),
+	"NZ": matching(digits4),
+	"CH": matching(digits4),
+	"AT": matching(digits4),
+	"BE": matching(digits4),
+	"DK": matching(digits4),
+	"SG": matching(digits6),
+}
+
+// matching accepts codes of the given shape as they are.
+func matching(pattern *regexp.Regexp) postcodeFormat {
+	return func(c string) (string, bool) {
+		return c, pattern.MatchString(c)
+	}
+}
+
+// split accepts codes of the given shape and puts sep after the first at
+// characters.
+func split(pattern *regexp.Regexp, at int, sep string) postcodeFormat {
+	return func(c string) (string, bool) {
+		if !pattern.MatchString(c) {
+			return "", false
+		}
+		return c[:at] + sep + c[at:], true
+	}
+}
+
+// NormalizePostalCode tidies a postal code for the country with the given
+// alpha-2 code: upper case, no stray separators, and the country's own
+// spacing or hyphen where it has one. Codes that do not look like the
+// country's are only trimmed, upper cased and collapsed, never rejected.
+func NormalizePostalCode(country, code string) string {
+	tidy := strings.ToUpper(strings.Join(strings.Fields(code), " "))
+	if tidy == "" {
+		return ""
+	}
+
+	format, ok := postcodeFormats[strings.ToUpper(country)]
+	if !ok {
+		return tidy
+	}
+
+	compact := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, tidy)
+
+	if formatted, ok := format(compact); ok {
+		return formatted
+	}
+	return tidy
+}
@@ -0,0 +1,198 @@
+package postal
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The bundled postal codes are a sample, not a postal directory: the main
+// post offices of large Indian cities and a few US ZIP codes. Deployments
+// that autofill addresses load a full directory instead, from the file
+// named by POSTAL_CODES_FILE in the same format; cmd/postcodeimport builds
+// one from the GeoNames postal code dump. With only the sample, codes
+// outside it are simply unknown, so lookups miss and autofill leaves the
+// address as typed.
+//
+//go:embed data/postcodes_sample.csv
+var postcodesCSV []byte
+
+// Coverage values report which postal codes are loaded.
+const (
+	CoverageSample = "sample"
+	CoverageFull   = "full"
+)
+
+var coverage = CoverageSample
+
+// Coverage reports whether lookups search the bundled sample or a full
+// directory loaded with LoadPostcodes.
+func Coverage() string {
+	return coverage
+}
+
+// Place is a locality served by a postal code. StateCode is the ISO
+// 3166-2 subdivision and State its name.
+type Place struct {
+	City      string
+	District  string
+	State     string
+	StateCode string
+}
+
+// places holds the loaded postal codes by country, then by normalized
+// code. A code may serve more than one place.
+var places = map[string]map[string][]Place{}
+
+func loadPostcodes() error {
+	loaded, err := readPostcodes(bytes.NewReader(postcodesCSV))
+	if err != nil {
+		return err
+	}
+
+	places = loaded
+	return nil
+}
+
+// LoadPostcodes replaces the bundled sample with the postal directory at
+// path, a CSV file with the columns country, code, city, district and
+// state, the latter an ISO 3166-2 code or subdivision name. It is not safe
+// to call while lookups run, so load it at startup.
+func LoadPostcodes(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	loaded, err := readPostcodes(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	places = loaded
+	coverage = CoverageFull
+	return nil
+}
+
+// LoadConfiguredPostcodes loads the directory named by POSTAL_CODES_FILE,
+// keeping the bundled sample when it is unset.
+func LoadConfiguredPostcodes() error {
+	path := os.Getenv("POSTAL_CODES_FILE")
+	if path == "" {
+		return nil
+	}
+	return LoadPostcodes(path)
+}
+
+func readPostcodes(r io.Reader) (map[string]map[string][]Place, error) {
+	loaded := map[string]map[string][]Place{}
+
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line++
+		if line == 1 {
+			continue
+		}
+
+		if len(record) != 5 {
+			return nil, fmt.Errorf("postcodes line %d: expected 5 columns, got %d", line, len(record))
+		}
+
+		country := strings.ToUpper(record[0])
+		subdivision, ok := LookupSubdivision(country, record[4])
+		if !ok {
+			return nil, fmt.Errorf("postcodes line %d: unknown subdivision %q", line, record[4])
+		}
+
+		if loaded[country] == nil {
+			loaded[country] = map[string][]Place{}
+		}
+
+		code := NormalizePostalCode(country, record[1])
+		loaded[country][code] = append(loaded[country][code], Place{
+			City:      record[2],
+			District:  record[3],
+			State:     subdivision.Name,
+			StateCode: subdivision.Code,
+		})
+	}
+
+	return loaded, nil
+}
+
+// LookupPostalCode returns the places the loaded postal codes list for a
+// postal code in the country with the given alpha-2 code. The code is
+// normalized first, so "400 001" finds 400001.
+func LookupPostalCode(country, code string) []Place {
+	country = strings.ToUpper(country)
+	return places[country][NormalizePostalCode(country, code)]
+}
+
+// AutofillEnabled reports whether missing cities and states are filled in
+// from the postal code on write, set with POSTAL_AUTOFILL=true.
+func AutofillEnabled() bool {
+	return os.Getenv("POSTAL_AUTOFILL") == "true"
+}
+
+// Autofill fills an empty city or state from the postal code. A field is
+// only filled when every place sharing the code agrees on it, so an
+// ambiguous code never picks one at random. It reports whether anything
+// changed.
+func Autofill(a *Address) bool {
+	if a.Pincode == "" || (a.City != "" && a.State != "") {
+		return false
+	}
+
+	country, ok := LookupCountry(a.Country)
+	if !ok {
+		return false
+	}
+
+	candidates := LookupPostalCode(country.Alpha2, a.Pincode)
+	if len(candidates) == 0 {
+		return false
+	}
+
+	changed := false
+
+	if a.City == "" {
+		if city, ok := agreed(candidates, func(p Place) string { return p.City }); ok {
+			a.City = city
+			changed = true
+		}
+	}
+	if a.State == "" {
+		if state, ok := agreed(candidates, func(p Place) string { return p.State }); ok {
+			a.State = state
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func agreed(candidates []Place, value func(Place) string) (string, bool) {
+	first := value(candidates[0])
+	for _, p := range candidates[1:] {
+		if value(p) != first {
+			return "", false
+		}
+	}
+	return first, true
+}
@@ -0,0 +1,157 @@
+package postal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupPostalCode(t *testing.T) {
+	places := LookupPostalCode("in", "400 001")
+	if len(places) != 1 {
+		t.Fatalf("LookupPostalCode(in, 400 001) = %d places, want 1", len(places))
+	}
+
+	want := Place{City: "Mumbai", District: "Mumbai", State: "Maharashtra", StateCode: "IN-MH"}
+	if places[0] != want {
+		t.Errorf("place = %+v, want %+v", places[0], want)
+	}
+
+	if places := LookupPostalCode("IN", "999999"); len(places) != 0 {
+		t.Errorf("LookupPostalCode(IN, 999999) = %+v, want none", places)
+	}
+	if places := LookupPostalCode("DE", "400001"); len(places) != 0 {
+		t.Errorf("LookupPostalCode(DE, 400001) = %+v, want none", places)
+	}
+}
+
+func TestAutofill(t *testing.T) {
+	// Two localities share this code but agree on the state.
+	places["IN"]["999001"] = []Place{
+		{City: "Alpha", State: "Goa", StateCode: "IN-GA"},
+		{City: "Beta", State: "Goa", StateCode: "IN-GA"},
+	}
+	t.Cleanup(func() { delete(places["IN"], "999001") })
+
+	tests := []struct {
+		name    string
+		in      Address
+		want    Address
+		changed bool
+	}{
+		{
+			name:    "fills city and state",
+			in:      Address{Country: "India", Pincode: "400 001"},
+			want:    Address{Country: "India", Pincode: "400 001", City: "Mumbai", State: "Maharashtra"},
+			changed: true,
+		},
+		{
+			name:    "keeps the city given",
+			in:      Address{Country: "IN", Pincode: "400001", City: "Bombay"},
+			want:    Address{Country: "IN", Pincode: "400001", City: "Bombay", State: "Maharashtra"},
+			changed: true,
+		},
+		{
+			name:    "only fields every place agrees on",
+			in:      Address{Country: "India", Pincode: "999001"},
+			want:    Address{Country: "India", Pincode: "999001", State: "Goa"},
+			changed: true,
+		},
+		{
+			name: "nothing missing",
+			in:   Address{Country: "India", Pincode: "400001", City: "Mumbai", State: "MH"},
+			want: Address{Country: "India", Pincode: "400001", City: "Mumbai", State: "MH"},
+		},
+		{
+			name: "unknown code",
+			in:   Address{Country: "India", Pincode: "999999"},
+			want: Address{Country: "India", Pincode: "999999"},
+		},
+		{
+			name: "unknown country",
+			in:   Address{Country: "Atlantis", Pincode: "400001"},
+			want: Address{Country: "Atlantis", Pincode: "400001"},
+		},
+		{
+			name: "no postal code",
+			in:   Address{Country: "India"},
+			want: Address{Country: "India"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.in
+			if changed := Autofill(&got); changed != tt.changed {
+				t.Errorf("Autofill changed = %v, want %v", changed, tt.changed)
+			}
+			if got != tt.want {
+				t.Errorf("Autofill = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutofillEnabled(t *testing.T) {
+	t.Setenv("POSTAL_AUTOFILL", "")
+	if AutofillEnabled() {
+		t.Error("AutofillEnabled() = true without POSTAL_AUTOFILL")
+	}
+
+	t.Setenv("POSTAL_AUTOFILL", "true")
+	if !AutofillEnabled() {
+		t.Error("AutofillEnabled() = false with POSTAL_AUTOFILL=true")
+	}
+}
+
+func TestLoadPostcodes(t *testing.T) {
+	t.Cleanup(func() {
+		if err := loadPostcodes(); err != nil {
+			t.Fatal(err)
+		}
+		coverage = CoverageSample
+	})
+
+	if Coverage() != CoverageSample {
+		t.Fatalf("Coverage() = %q before loading, want %q", Coverage(), CoverageSample)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "postcodes.csv")
+	directory := "country,code,city,district,state\n" +
+		"IN,744 101,Port Blair,South Andaman,IN-AN\n" +
+		"IN,744101,Haddo,South Andaman,Andaman & Nicobar Islands\n"
+	if err := os.WriteFile(path, []byte(directory), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("POSTAL_CODES_FILE", path)
+	if err := LoadConfiguredPostcodes(); err != nil {
+		t.Fatalf("LoadConfiguredPostcodes() error = %v", err)
+	}
+
+	if Coverage() != CoverageFull {
+		t.Errorf("Coverage() = %q after loading, want %q", Coverage(), CoverageFull)
+	}
+	if places := LookupPostalCode("IN", "744101"); len(places) != 2 || places[1].StateCode != "IN-AN" {
+		t.Errorf("LookupPostalCode(IN, 744101) = %+v, want both Andaman places", places)
+	}
+	// The directory replaces the sample rather than adding to it.
+	if places := LookupPostalCode("IN", "400001"); len(places) != 0 {
+		t.Errorf("LookupPostalCode(IN, 400001) = %+v, want none", places)
+	}
+
+	bad := filepath.Join(dir, "bad.csv")
+	if err := os.WriteFile(bad, []byte("country,code,city,district,state\nIN,744101,Port Blair,South Andaman,Narnia\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadPostcodes(bad); err == nil {
+		t.Error("LoadPostcodes accepted an unknown subdivision")
+	}
+	if places := LookupPostalCode("IN", "744101"); len(places) != 2 {
+		t.Errorf("a failed load changed the loaded postal codes: %+v", places)
+	}
+	if err := LoadPostcodes(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("LoadPostcodes accepted a missing file")
+	}
+}
@@ -0,0 +1,152 @@
+package postal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Address holds the postal fields the rules check.
+type Address struct {
+	AddressLine1 string
+	AddressLine2 string
+	City         string
+	State        string
+	Country      string
+	Pincode      string
+}
+
+// Rule describes what a valid address in one country looks like. Postal
+// codes are matched after NormalizePostalCode, so the pattern only needs to
+// accept the printed form.
+type Rule struct {
+	PostalCode        *regexp.Regexp
+	PostalCodeExample string
+	// Required lists fields, by JSON name, that must not be empty.
+	Required []string
+	// MaxLength overrides DefaultMaxLength for the country.
+	MaxLength map[string]int
+}
+
+// DefaultMaxLength is the length limit, in characters, of each field in
+// every country. It matches the column sizes.
+var DefaultMaxLength = map[string]int{
+	"address_line1": 255,
+	"address_line2": 255,
+	"city":          100,
+	"state":         100,
+	"country":       100,
+	"pincode":       20,
+}
+
+// Rules holds the rules of each country by alpha-2 code. Addresses in other
+// countries, or without a recognizable country, only get the default
+// length limits.
+var Rules = map[string]Rule{
+	"IN": {
+		PostalCode:        regexp.MustCompile(`^[1-9]\d{5}$`),
+		PostalCodeExample: "110001",
+		Required:          []string{"city", "state", "pincode"},
+	},
+	"US": {
+		PostalCode:        regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+		PostalCodeExample: "94103 or 94103-1234",
+		Required:          []string{"city", "state", "pincode"},
+		// USPS abbreviates longer city names.
+		MaxLength: map[string]int{"city": 28},
+	},
+	"CA": {
+		PostalCode:        regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[A-Z] \d[A-Z]\d$`),
+		PostalCodeExample: "K1A 0B1",
+		Required:          []string{"city", "state", "pincode"},
+		// Canada Post prints at most 40 characters per line.
+		MaxLength: map[string]int{"address_line1": 40, "address_line2": 40, "city": 40},
+	},
+	"AU": {
+		PostalCode:        regexp.MustCompile(`^\d{4}$`),
+		PostalCodeExample: "2000",
+		Required:          []string{"city", "state", "pincode"},
+	},
+	"GB": {
+		PostalCode:        regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? \d[A-Z]{2}$`),
+		PostalCodeExample: "SW1A 1AA",
+		Required:          []string{"city", "pincode"},
+	},
+	"DE": {
+		PostalCode:        regexp.MustCompile(`^\d{5}$`),
+		PostalCodeExample: "10115",
+		Required:          []string{"city", "pincode"},
+	},
+	"FR": {
+		PostalCode:        regexp.MustCompile(`^\d{5}$`),
+		PostalCodeExample: "75001",
+		Required:          []string{"city", "pincode"},
+	},
+	"NL": {
+		PostalCode:        regexp.MustCompile(`^[1-9]\d{3} [A-Z]{2}$`),
+		PostalCodeExample: "1012 AB",
+		Required:          []string{"city", "pincode"},
+	},
+	"JP": {
+		PostalCode:        regexp.MustCompile(`^\d{3}-\d{4}$`),
+		PostalCodeExample: "100-0001",
+		Required:          []string{"state", "pincode"},
+	},
+	"BR": {
+		PostalCode:        regexp.MustCompile(`^\d{5}-\d{3}$`),
+		PostalCodeExample: "01310-100",
+		Required:          []string{"city", "state", "pincode"},
+	},
+	"SG": {
+		PostalCode:        regexp.MustCompile(`^\d{6}$`),
+		PostalCodeExample: "018956",
+		Required:          []string{"pincode"},
+	},
+}
+
+// Validate checks an address against the rules of its country and returns
+// a message per failing field, keyed by JSON name. Free-text countries are
+// accepted; they just have no country rules.
+func Validate(a Address) map[string]string {
+	details := map[string]string{}
+
+	values := map[string]string{
+		"address_line1": a.AddressLine1,
+		"address_line2": a.AddressLine2,
+		"city":          a.City,
+		"state":         a.State,
+		"country":       a.Country,
+		"pincode":       a.Pincode,
+	}
+
+	country, known := LookupCountry(a.Country)
+	rule := Rules[country.Alpha2]
+
+	for field, limit := range DefaultMaxLength {
+		if override, ok := rule.MaxLength[field]; ok {
+			limit = override
+		}
+		if utf8.RuneCountInString(strings.TrimSpace(values[field])) > limit {
+			details[field] = fmt.Sprintf("Must be at most %d characters long", limit)
+		}
+	}
+
+	if !known {
+		return details
+	}
+
+	for _, field := range rule.Required {
+		if strings.TrimSpace(values[field]) == "" {
+			details[field] = "This field is required for addresses in " + country.Name
+		}
+	}
+
+	if _, reported := details["pincode"]; !reported && rule.PostalCode != nil && strings.TrimSpace(a.Pincode) != "" {
+		if !rule.PostalCode.MatchString(NormalizePostalCode(country.Alpha2, a.Pincode)) {
+			details["pincode"] = "Must be a valid postal code for " + country.Name + ", such as " + rule.PostalCodeExample
+		}
+	}
+
+	return details
+}
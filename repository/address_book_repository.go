@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AddressBookRepository interface {
+	Create(book *model.AddressBook) error
+	FindByID(id uint64) (*model.AddressBook, error)
+	FindByUser(userID uint64) ([]model.AddressBook, error)
+	FindByIDAndUser(id, userID uint64) (*model.AddressBook, error)
+	FindByNameAndUser(name string, userID uint64) (*model.AddressBook, error)
+	FindOrCreateDefault(userID uint64) (*model.AddressBook, error)
+	CountAddresses(id uint64) (int64, error)
+	Update(book *model.AddressBook) error
+	Delete(id, userID uint64) error
+}
+
+type addressBookRepository struct {
+	db *gorm.DB
+}
+
+func NewAddressBookRepository(db *gorm.DB) AddressBookRepository {
+	return &addressBookRepository{db: db}
+}
+
+func (repository *addressBookRepository) Create(book *model.AddressBook) error {
+	return repository.db.Create(book).Error
+}
+
+func (repository *addressBookRepository) FindByID(id uint64) (*model.AddressBook, error) {
+	var book model.AddressBook
+
+	err := repository.db.Where("id = ?", id).First(&book).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+func (repository *addressBookRepository) FindByUser(userID uint64) ([]model.AddressBook, error) {
+	var books []model.AddressBook
+
+	err := repository.db.Where("user_id = ?", userID).Order("is_default DESC, name ASC").Find(&books).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (repository *addressBookRepository) FindByIDAndUser(id, userID uint64) (*model.AddressBook, error) {
+	var book model.AddressBook
+
+	err := repository.db.Where("id = ? AND user_id = ?", id, userID).First(&book).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+func (repository *addressBookRepository) FindByNameAndUser(name string, userID uint64) (*model.AddressBook, error) {
+	var book model.AddressBook
+
+	err := repository.db.Where("LOWER(name) = LOWER(?) AND user_id = ?", name, userID).First(&book).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+// FindOrCreateDefault returns the user's default book, creating it on first
+// use. Concurrent callers race on the (user_id, name) index, so the insert
+// ignores conflicts and the book is read back afterwards.
+func (repository *addressBookRepository) FindOrCreateDefault(userID uint64) (*model.AddressBook, error) {
+	var book model.AddressBook
+
+	err := repository.db.Where("user_id = ? AND is_default = true", userID).First(&book).Error
+	if err == nil {
+		return &book, nil
+	}
+
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	book = model.AddressBook{
+		UserID:    userID,
+		Name:      model.DefaultAddressBookName,
+		IsDefault: true,
+	}
+
+	if err := repository.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&book).Error; err != nil {
+		return nil, err
+	}
+
+	if book.ID != 0 {
+		return &book, nil
+	}
+
+	if err := repository.db.Where("user_id = ? AND is_default = true", userID).First(&book).Error; err != nil {
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+func (repository *addressBookRepository) CountAddresses(id uint64) (int64, error) {
+	var count int64
+
+	err := repository.db.Model(&model.Address{}).Where("book_id = ? AND is_deleted = false", id).Count(&count).Error
+
+	return count, err
+}
+
+func (repository *addressBookRepository) Update(book *model.AddressBook) error {
+	return repository.db.Save(book).Error
+}
+
+func (repository *addressBookRepository) Delete(id, userID uint64) error {
+	return repository.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.AddressBook{}).Error
+}
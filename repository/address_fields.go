@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"address-book-server/dto"
+	"address-book-server/utils"
+
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// addressFieldPreloads maps fieldset names backed by child rows to their
+// association. Every other fieldset name is an addresses column.
+var addressFieldPreloads = map[string]string{
+	"tags":             "Tags",
+	"emails":           "Emails",
+	"phones":           "Phones",
+	"postal_addresses": "PostalAddresses",
+}
+
+// addressColumns lists the columns to select for a sparse fieldset: the
+// requested ones plus the id, which preloads join on, and extra, such as
+// the sort columns cursors are built from. No fields means every column.
+func addressColumns(fields []string, extra ...string) string {
+	if len(fields) == 0 {
+		return "addresses.*"
+	}
+
+	seen := map[string]bool{"id": true}
+	columns := []string{"addresses.id"}
+
+	for _, field := range append(append([]string{}, fields...), extra...) {
+		if _, ok := addressFieldPreloads[field]; ok || seen[field] {
+			continue
+		}
+		seen[field] = true
+		columns = append(columns, "addresses."+field)
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// preloadAddressFields loads the associations a fieldset asks for, or all
+// of them when no fieldset was given.
+func preloadAddressFields(db *gorm.DB, fields []string) *gorm.DB {
+	if len(fields) == 0 {
+		return withContactDetails(db).Preload("Tags")
+	}
+
+	for _, field := range fields {
+		association, ok := addressFieldPreloads[field]
+		if !ok {
+			continue
+		}
+
+		if association == "Tags" {
+			db = db.Preload(association)
+		} else {
+			db = db.Preload(association, primaryFirst)
+		}
+	}
+
+	return db
+}
+
+// sortColumns returns the address columns behind sort fields, leaving out
+// pseudo fields like relevance.
+func sortColumns(fields []dto.SortField) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if _, ok := utils.AllowedAddressSortFields[f.Field]; ok {
+			columns = append(columns, f.Field)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"address-book-server/dto"
+	"address-book-server/utils"
+
+	"math"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// kmPerDegree is the length of one degree of latitude, and of longitude at
+// the equator.
+const kmPerDegree = math.Pi * utils.EarthRadiusKm / 180
+
+// haversine is the great-circle distance in km from the near point, taking
+// its latitude, latitude and longitude as arguments. LEAST guards asin
+// against rounding just above 1.
+var haversine = "(2 * " + strconv.FormatFloat(utils.EarthRadiusKm, 'f', -1, 64) + " * ASIN(LEAST(1, SQRT(" +
+	"POWER(SIN(RADIANS(addresses.latitude - ?) / 2), 2) + " +
+	"COS(RADIANS(?)) * COS(RADIANS(addresses.latitude)) * POWER(SIN(RADIANS(addresses.longitude - ?) / 2), 2)))))"
+
+// filterByGeo restricts the query to the bbox and to radius_km around the
+// near point, and sets up the distance expression for sorting. Both filters
+// start with plain latitude and longitude ranges the location index can
+// answer; the exact haversine test only runs on what is left.
+func filterByGeo(db *gorm.DB, query dto.ListAddressQuery, sorter *sortContext) *gorm.DB {
+	if query.Point == nil && query.Box == nil {
+		return db
+	}
+
+	db = db.Where("addresses.latitude IS NOT NULL AND addresses.longitude IS NOT NULL")
+
+	if query.Box != nil {
+		db = withinBox(db, *query.Box)
+	}
+
+	if query.Point == nil {
+		return db
+	}
+
+	point := *query.Point
+	sorter.distance = haversine
+	sorter.distanceArgs = []interface{}{point.Lat, point.Lat, point.Lng}
+
+	if query.RadiusKm > 0 {
+		db = withinBox(db, circleBox(point, query.RadiusKm))
+		db = db.Where(haversine+" <= ?", point.Lat, point.Lat, point.Lng, query.RadiusKm)
+	}
+
+	return db
+}
+
+func withinBox(db *gorm.DB, box dto.GeoBox) *gorm.DB {
+	db = db.Where("addresses.latitude BETWEEN ? AND ?", box.South, box.North)
+
+	switch {
+	case box.West <= -180 && box.East >= 180:
+		return db
+	case box.West > box.East:
+		// The box crosses the antimeridian.
+		return db.Where("(addresses.longitude >= ? OR addresses.longitude <= ?)", box.West, box.East)
+	}
+
+	return db.Where("addresses.longitude BETWEEN ? AND ?", box.West, box.East)
+}
+
+// circleBox bounds the circle of radiusKm around point, for the range
+// filters. Its latitudes are exact; its longitudes are those of the two
+// points where the circle touches a meridian, asin(sin d / cos lat) either
+// side for an angular radius d, which lie poleward of the centre and so
+// reach further than d / cos lat. A circle that covers a pole spans every
+// longitude.
+func circleBox(point dto.GeoPoint, radiusKm float64) dto.GeoBox {
+	latDelta := radiusKm / kmPerDegree
+	box := dto.GeoBox{
+		South: math.Max(point.Lat-latDelta, -90),
+		North: math.Min(point.Lat+latDelta, 90),
+		West:  -180,
+		East:  180,
+	}
+
+	if box.South == -90 || box.North == 90 {
+		return box
+	}
+
+	d := radiusKm / utils.EarthRadiusKm
+	sinD, cosLat := math.Sin(d), math.Cos(point.Lat*math.Pi/180)
+	if sinD >= cosLat {
+		return box
+	}
+
+	lngDelta := math.Asin(sinD/cosLat) * 180 / math.Pi
+
+	box.West = normalizeLongitude(point.Lng - lngDelta)
+	box.East = normalizeLongitude(point.Lng + lngDelta)
+
+	return box
+}
+
+func normalizeLongitude(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	}
+	return lng
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"address-book-server/dto"
+	"address-book-server/utils"
+
+	"math"
+	"testing"
+)
+
+// destination is the point radiusKm from point along the bearing, in degrees.
+func destination(point dto.GeoPoint, radiusKm, bearing float64) dto.GeoPoint {
+	rad := math.Pi / 180
+	d := radiusKm / utils.EarthRadiusKm
+	lat1, lng1, b := point.Lat*rad, point.Lng*rad, bearing*rad
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(b))
+	lng2 := lng1 + math.Atan2(math.Sin(b)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+
+	return dto.GeoPoint{Lat: lat2 / rad, Lng: normalizeLongitude(lng2 / rad)}
+}
+
+func inBox(box dto.GeoBox, p dto.GeoPoint) bool {
+	const eps = 1e-9
+	if p.Lat < box.South-eps || p.Lat > box.North+eps {
+		return false
+	}
+	if box.West > box.East {
+		return p.Lng >= box.West-eps || p.Lng <= box.East+eps
+	}
+	return p.Lng >= box.West-eps && p.Lng <= box.East+eps
+}
+
+func TestCircleBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		point    dto.GeoPoint
+		radiusKm float64
+		fullLng  bool
+	}{
+		{"equator", dto.GeoPoint{Lat: 0, Lng: 0}, 1000, false},
+		{"mid latitude", dto.GeoPoint{Lat: 19.076, Lng: 72.8777}, 50, false},
+		{"high latitude", dto.GeoPoint{Lat: 60, Lng: 10}, 3000, false},
+		{"southern", dto.GeoPoint{Lat: -45, Lng: 170}, 800, false},
+		{"across the antimeridian", dto.GeoPoint{Lat: 52, Lng: 179.5}, 400, false},
+		{"covers the pole", dto.GeoPoint{Lat: 89, Lng: 0}, 200, true},
+		{"whole earth", dto.GeoPoint{Lat: 12, Lng: 77}, 20040, true},
+	}
+
+	for _, tt := range tests {
+		box := circleBox(tt.point, tt.radiusKm)
+
+		if full := box.West == -180 && box.East == 180; full != tt.fullLng {
+			t.Errorf("%s: box %+v spans every longitude = %v, want %v", tt.name, box, full, tt.fullLng)
+		}
+
+		widest := 0.0
+		for bearing := 0.0; bearing < 360; bearing += 0.25 {
+			p := destination(tt.point, tt.radiusKm, bearing)
+			if !inBox(box, p) {
+				t.Errorf("%s: %+v at bearing %v is outside %+v", tt.name, p, bearing, box)
+				break
+			}
+			widest = math.Max(widest, math.Abs(normalizeLongitude(p.Lng-tt.point.Lng)))
+		}
+
+		// The box is no wider than the circle.
+		if !tt.fullLng {
+			half := normalizeLongitude(box.East - tt.point.Lng)
+			if half-widest > 0.01 {
+				t.Errorf("%s: box reaches %v degrees east, the circle only %v", tt.name, half, widest)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"address-book-server/dto"
+	"address-book-server/utils"
+
+	"strings"
+)
+
+// sortContext turns sort fields into SQL. relevance is the score expression
+// of a ranked search and is only set in those search modes; distance is
+// only set when listing near a point.
+type sortContext struct {
+	collation     string
+	relevance     string
+	relevanceArgs []interface{}
+	distance      string
+	distanceArgs  []interface{}
+}
+
+// expression returns the SQL a field sorts by. Text columns compare
+// lower-cased under the configured collation so that case does not split
+// otherwise equal names.
+func (c sortContext) expression(field string) (string, []interface{}) {
+	if field == utils.RelevanceSortField {
+		return c.relevance, c.relevanceArgs
+	}
+	if field == utils.DistanceSortField {
+		return c.distance, c.distanceArgs
+	}
+
+	return c.normalize("addresses."+field, field), nil
+}
+
+// normalize applies the same text normalization to expr, which is either a
+// column or a placeholder, so cursor values compare exactly like the rows
+// they came from.
+func (c sortContext) normalize(expr, field string) string {
+	if !utils.AllowedAddressSortFields[field] {
+		return expr
+	}
+
+	if expr == "?" {
+		expr = "CAST(? AS text)"
+	}
+
+	return utils.SortKey(expr, c.collation)
+}
+
+func (c sortContext) order(fields []dto.SortField) (string, []interface{}) {
+	if len(fields) == 0 {
+		return "addresses.created_at DESC, addresses.id DESC", nil
+	}
+
+	parts := make([]string, 0, len(fields))
+	args := []interface{}{}
+
+	for _, f := range fields {
+		expr, exprArgs := c.expression(f.Field)
+		args = append(args, exprArgs...)
+
+		if f.Desc {
+			expr += " DESC"
+		} else {
+			expr += " ASC"
+		}
+
+		parts = append(parts, expr)
+	}
+
+	return strings.Join(parts, ", "), args
+}
+
+// keyset selects the rows strictly after (or before) the cursor row in the
+// given order. With mixed directions a row comparison is not enough, so it
+// expands to (a > ?) OR (a = ? AND b < ?) OR ...
+func (c sortContext) keyset(fields []dto.SortField, values []interface{}, before bool) (string, []interface{}) {
+	clauses := make([]string, 0, len(fields))
+	args := []interface{}{}
+
+	for i, f := range fields {
+		parts := make([]string, 0, i+1)
+
+		for j := 0; j <= i; j++ {
+			op := "="
+			if j == i {
+				op = ">"
+				if f.Desc != before {
+					op = "<"
+				}
+			}
+
+			expr, exprArgs := c.expression(fields[j].Field)
+			args = append(args, exprArgs...)
+			args = append(args, values[j])
+
+			parts = append(parts, expr+" "+op+" "+c.normalize("?", fields[j].Field))
+		}
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+func reverseSort(fields []dto.SortField) []dto.SortField {
+	reversed := make([]dto.SortField, len(fields))
+
+	for i, f := range fields {
+		reversed[i] = dto.SortField{Field: f.Field, Desc: !f.Desc}
+	}
+
+	return reversed
+}
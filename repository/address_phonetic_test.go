@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPhoneticCondition(t *testing.T) {
+	tests := []struct {
+		word      string
+		condition string
+		args      []interface{}
+	}{
+		{
+			"Smith",
+			"(addresses.first_name_metaphone IN ? OR addresses.first_name_metaphone_alt IN ? OR " +
+				"addresses.last_name_metaphone IN ? OR addresses.last_name_metaphone_alt IN ? OR " +
+				"addresses.first_name_soundex = ? OR addresses.last_name_soundex = ?)",
+			[]interface{}{
+				[]string{"SM0", "XMT"}, []string{"SM0", "XMT"}, []string{"SM0", "XMT"}, []string{"SM0", "XMT"},
+				"S530", "S530",
+			},
+		},
+		// No Metaphone code, so only Soundex is compared.
+		{
+			"H",
+			"(addresses.first_name_soundex = ? OR addresses.last_name_soundex = ?)",
+			[]interface{}{"H000", "H000"},
+		},
+		// Names in other scripts have no codes and match by name key.
+		{"राहुल", "addresses.name_key LIKE ?", []interface{}{"%राहुल%"}},
+		{"王", "addresses.name_key LIKE ?", []interface{}{"%王%"}},
+	}
+
+	for _, tt := range tests {
+		condition, args := phoneticCondition(tt.word)
+		if condition != tt.condition {
+			t.Errorf("phoneticCondition(%q) = %q, want %q", tt.word, condition, tt.condition)
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("phoneticCondition(%q) args = %v, want %v", tt.word, args, tt.args)
+		}
+	}
+}
+
+// An empty code must never be compared: every name without a code stores
+// one, so राहुल would match 王 and "H" every name in another script.
+func TestPhoneticConditionNeverComparesEmptyCodes(t *testing.T) {
+	for _, word := range []string{"राहुल", "王", "Иван", "H", "W", "Hwa", "Smith", "Müller"} {
+		_, args := phoneticCondition(word)
+
+		for _, arg := range args {
+			values, ok := arg.([]string)
+			if !ok {
+				values = []string{arg.(string)}
+			}
+
+			for _, value := range values {
+				if value == "" || value == "%%" {
+					t.Errorf("phoneticCondition(%q) args = %v, which match every name without a code", word, args)
+				}
+			}
+		}
+	}
+}
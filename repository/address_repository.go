@@ -2,18 +2,47 @@ package repository
 
 import (
 	"address-book-server/dto"
+	"address-book-server/filter"
 	"address-book-server/model"
+	"address-book-server/utils"
+	"strconv"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AddressRepository interface {
 	Create(address *model.Address) error
 	FindByUser(userID uint64) ([]model.Address, error)
 	FindByIDAndUser(id, userID uint64) (*model.Address, error)
+	FindByIDWithFields(id, userID uint64, fields []string) (*model.Address, error)
 	Update(address *model.Address) error
 	SoftDelete(id, userID uint64) error
-	FindUserWithFilters(userId uint64, query dto.ListAddressQuery) ([]model.Address, int64, error)
+	FindUserWithFilters(userId uint64, query dto.ListAddressQuery) (*AddressPage, error)
+	CountByIDsAndUser(ids []uint64, userID uint64) (int64, error)
+	UpdatePhoto(id, userID uint64, photoKey, thumbnailKey, contentType string) error
+	FindWithEvents(userID uint64) ([]model.Address, error)
+	FindByUserAndGroup(userID, groupID uint64, includeSubgroups bool) ([]model.Address, error)
+	FindByIDsAndUser(ids []uint64, userID uint64) ([]model.Address, error)
+	MoveToBook(ids []uint64, userID, bookID uint64) error
+	CopyAll(addresses []model.Address) error
+	FindByID(id uint64) (*model.Address, error)
+	UpdateGeocode(address *model.Address) error
+	FindPendingGeocodeIDs() ([]uint64, error)
+}
+
+const tsQuery = "websearch_to_tsquery('" + utils.TextSearchConfig + "', ?)"
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=12, MinWords=4"
+
+type AddressPage struct {
+	Addresses []model.Address
+	// Total is -1 when the query skipped counting.
+	Total int64
+	// HasMore reports whether rows remain past this page in the direction
+	// of travel.
+	HasMore bool
 }
 
 type addressRepository struct {
@@ -32,7 +61,24 @@ func (repository *addressRepository) Create(address *model.Address) error {
 func (repository *addressRepository) FindByIDAndUser(id uint64, userID uint64) (*model.Address, error) {
 	var address model.Address
 
-	err := repository.db.Where("id = ? AND user_id = ? AND is_deleted = false", id, userID).First(&address).Error
+	err := withContactDetails(repository.db).Where("id = ? AND user_id = ? AND is_deleted = false", id, userID).First(&address).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+// FindByIDWithFields loads a contact with only the columns and associations
+// of a sparse fieldset, plus its book for access checks.
+func (repository *addressRepository) FindByIDWithFields(id uint64, userID uint64, fields []string) (*model.Address, error) {
+	var address model.Address
+
+	err := preloadAddressFields(repository.db, fields).
+		Select(addressColumns(fields, "book_id")).
+		Where("id = ? AND user_id = ? AND is_deleted = false", id, userID).
+		First(&address).Error
 
 	if err != nil {
 		return nil, err
@@ -44,7 +90,7 @@ func (repository *addressRepository) FindByIDAndUser(id uint64, userID uint64) (
 func (repository *addressRepository) FindByUser(userID uint64) ([]model.Address, error) {
 	var addresses []model.Address
 
-	err := repository.db.Where("user_id = ? AND is_deleted = false", userID).Find(&addresses).Error
+	err := withContactDetails(repository.db).Preload("Tags").Where("user_id = ? AND is_deleted = false", userID).Find(&addresses).Error
 
 	if err != nil {
 		return nil, err
@@ -57,31 +103,140 @@ func (repository *addressRepository) SoftDelete(id uint64, userID uint64) error
 	return repository.db.Model(&model.Address{}).Where("id = ? AND user_id = ?", id, userID).Update("is_deleted", true).Error
 }
 
+// detachedColumns are written only by the geocoder and the photo endpoints,
+// through UpdateGeocode and UpdatePhoto. Update leaves them out, so saving a
+// contact loaded earlier in the request cannot undo a concurrent write.
+var detachedColumns = []string{
+	"latitude", "longitude", "geocode_status", "geocode_accuracy", "geocoded_at",
+	"photo_key", "thumbnail_key", "photo_content_type",
+}
+
 func (repository *addressRepository) Update(address *model.Address) error {
-	return repository.db.Save(address).Error
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(append([]string{clause.Associations}, detachedColumns...)...).Save(address).Error; err != nil {
+			return err
+		}
+
+		return replaceChildren(tx, address)
+	})
+}
+
+func (repository *addressRepository) UpdatePhoto(id, userID uint64, photoKey, thumbnailKey, contentType string) error {
+	return repository.db.Model(&model.Address{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{
+			"photo_key":          photoKey,
+			"thumbnail_key":      thumbnailKey,
+			"photo_content_type": contentType,
+		}).Error
+}
+
+func (repository *addressRepository) FindUserWithFilters(userId uint64, query dto.ListAddressQuery) (*AddressPage, error) {
+	if query.Search == "" || query.SearchMode != utils.SearchModeFuzzy {
+		return repository.findWithFilters(repository.db, userId, query)
+	}
+
+	var page *AddressPage
+
+	// The <% operator reads its threshold from the session, so it is set
+	// locally for a transaction that runs the whole listing.
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		threshold := strconv.FormatFloat(query.Threshold, 'f', -1, 64)
+
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", threshold).Error; err != nil {
+			return err
+		}
+
+		var err error
+		page, err = repository.findWithFilters(tx, userId, query)
+		return err
+	})
+
+	return page, err
 }
 
-func (repository *addressRepository) FindUserWithFilters(userId uint64, query dto.ListAddressQuery) ([]model.Address, int64, error) {
+func (repository *addressRepository) findWithFilters(db *gorm.DB, userId uint64, query dto.ListAddressQuery) (*AddressPage, error) {
 	var addresses []model.Address
-	var total int64
+	total := int64(-1)
+
+	db = db.Model(&model.Address{}).Where("user_id = ? AND is_deleted = false", userId)
+
+	if query.BookID != 0 {
+		db = db.Where("book_id = ?", query.BookID)
+	}
 
-	db := repository.db.Model(&model.Address{}).Where("user_id = ? AND is_deleted = false", userId)
+	sorter := sortContext{collation: utils.SortCollation()}
 
-	if query.Search != "" {
+	if query.Search != "" && query.SearchMode == utils.SearchModeFulltext {
+		db = db.Where("addresses.search_vector @@ "+tsQuery, query.Search)
+		sorter.relevance = "ts_rank_cd(addresses.search_vector, " + tsQuery + ")"
+		sorter.relevanceArgs = []interface{}{query.Search}
+	} else if query.Search != "" && query.SearchMode == utils.SearchModeFuzzy {
+		db = db.Where(
+			"(? <% "+utils.AddressFullName+" OR ? <% addresses.email OR ? <% addresses.city)",
+			query.Search, query.Search, query.Search,
+		)
+		sorter.relevance = "GREATEST(word_similarity(?, " + utils.AddressFullName + "), " +
+			"word_similarity(?, addresses.email), COALESCE(word_similarity(?, addresses.city), 0))"
+		sorter.relevanceArgs = []interface{}{query.Search, query.Search, query.Search}
+	} else if query.Search != "" && query.Phonetic {
+		db = repository.filterByPhonetic(db, query.Search)
+	} else if query.Search != "" {
 		like := "%" + query.Search + "%"
-		db = db.Where("first_name ILIKE ? OR last_name ILIKE ? OR email ILIKE ? OR phone ILIKE ?", like, like, like, like)
+		condition := "addresses.name_key LIKE ? OR first_name ILIKE ? OR last_name ILIKE ? OR " +
+			"EXISTS (SELECT 1 FROM address_emails WHERE address_emails.address_id = addresses.id AND address_emails.email ILIKE ?) OR " +
+			"EXISTS (SELECT 1 FROM address_phones WHERE address_phones.address_id = addresses.id AND address_phones.phone ILIKE ?) OR " +
+			"EXISTS (SELECT 1 FROM notes WHERE notes.address_id = addresses.id AND notes.is_deleted = false AND notes.body ILIKE ?)"
+		args := []interface{}{"%" + query.SearchKey + "%", like, like, like, like, like}
+
+		// Numbers also match by digits, however they were typed.
+		if query.PhoneDigits != "" {
+			condition += " OR EXISTS (SELECT 1 FROM address_phones WHERE address_phones.address_id = addresses.id AND address_phones.digits LIKE ?)"
+			args = append(args, "%"+query.PhoneDigits+"%")
+		}
+
+		db = db.Where("("+condition+")", args...)
+	}
+
+	db = filterByGeo(db, query, &sorter)
+
+	if query.FilterExpr != nil {
+		condition, args := filter.Compile(query.FilterExpr)
+		db = db.Where(condition, args...)
 	}
 
 	if query.City != "" {
-		db = db.Where("city ILIKE ?", query.City)
+		db = db.Where("EXISTS (SELECT 1 FROM postal_addresses WHERE postal_addresses.address_id = addresses.id AND (postal_addresses.city_key = ? OR postal_addresses.city ILIKE ?))", query.CityKey, query.City)
 	}
 
 	if query.Country != "" {
-		db = db.Where("country ILIKE ?", query.Country)
+		db = db.Where("EXISTS (SELECT 1 FROM postal_addresses WHERE postal_addresses.address_id = addresses.id AND (postal_addresses.country_key = ? OR postal_addresses.country ILIKE ? OR postal_addresses.country_code = ?))", query.CountryKey, query.Country, query.CountryCode)
 	}
 
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
+	for key, value := range query.CustomFields {
+		db = db.Where(
+			"EXISTS (SELECT 1 FROM custom_field_values JOIN custom_fields ON custom_fields.id = custom_field_values.field_id "+
+				"WHERE custom_field_values.address_id = addresses.id AND custom_fields.user_id = ? AND custom_fields.key = ? AND LOWER(custom_field_values.value) = LOWER(?))",
+			userId, key, value,
+		)
+	}
+
+	if query.EventType != "" || query.EventRange != nil {
+		db = repository.filterByEvents(db, query.EventType, query.EventRange)
+	}
+
+	if len(query.Tags) > 0 {
+		db = repository.filterByTags(db, userId, query.Tags, query.TagMode)
+	}
+
+	if query.GroupID != 0 {
+		db = db.Where("id IN (?)", repository.groupMembers(userId, query.GroupID, query.IncludeSubgroups))
+	}
+
+	if query.Count == nil || *query.Count {
+		if err := db.Count(&total).Error; err != nil {
+			return nil, err
+		}
 	}
 	
 	page := query.Page
@@ -95,9 +250,360 @@ func (repository *addressRepository) FindUserWithFilters(userId uint64, query dt
 		limit = 10
 	}
 
-	offset := (page-1) * limit
+	fields := query.SortFields
+
+	columns := addressColumns(query.SelectFields, sortColumns(fields)...)
+
+	selectArgs := []interface{}{}
+
+	if sorter.relevance != "" && query.SearchMode == utils.SearchModeFulltext {
+		columns += ", " + sorter.relevance + " AS search_score, " +
+			"ts_headline('" + utils.TextSearchConfig + "', " + utils.AddressSearchDocument + ", " + tsQuery + ", '" + headlineOptions + "') AS search_highlight"
+		selectArgs = append(selectArgs, query.Search, query.Search)
+	} else if sorter.relevance != "" {
+		columns += ", " + sorter.relevance + " AS search_score"
+		selectArgs = append(selectArgs, sorter.relevanceArgs...)
+	}
+
+	if sorter.distance != "" {
+		columns += ", " + sorter.distance + " AS distance_km"
+		selectArgs = append(selectArgs, sorter.distanceArgs...)
+	}
+
+	db = db.Select(columns, selectArgs...)
+
+	if len(query.Keyset) > 0 {
+		condition, args := sorter.keyset(fields, query.Keyset, query.KeysetBefore)
+		db = db.Where(condition, args...)
+	} else {
+		db = db.Offset((page-1) * limit)
+	}
+
+	// Before cursors walk the list backwards, so the order is flipped for
+	// the query and the rows are put back in display order afterwards.
+	if query.KeysetBefore {
+		fields = reverseSort(fields)
+	}
+
+	// One extra row tells whether another page follows.
+	order, orderArgs := sorter.order(fields)
+	err := preloadAddressFields(db, query.SelectFields).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: order, Vars: orderArgs, WithoutParentheses: true}}).
+		Limit(limit + 1).
+		Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(addresses) > limit
+	if hasMore {
+		addresses = addresses[:limit]
+	}
+
+	if query.KeysetBefore {
+		for i, j := 0, len(addresses)-1; i < j; i, j = i+1, j-1 {
+			addresses[i], addresses[j] = addresses[j], addresses[i]
+		}
+	}
+
+	return &AddressPage{Addresses: addresses, Total: total, HasMore: hasMore}, nil
+}
+
+func (repository *addressRepository) filterByTags(db *gorm.DB, userId uint64, tags []string, mode string) *gorm.DB {
+	seen := make(map[string]bool, len(tags))
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		name := strings.ToLower(strings.TrimSpace(t))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return db
+	}
+
+	sub := repository.db.Table("address_tags").
+		Select("address_tags.address_id").
+		Joins("JOIN tags ON tags.id = address_tags.tag_id").
+		Where("tags.user_id = ? AND LOWER(tags.name) IN ?", userId, names)
 
-	err := db.Order("created_at DESC").Limit(limit).Offset(offset).Find(&addresses).Error
+	if mode == "all" {
+		sub = sub.Group("address_tags.address_id").Having("COUNT(DISTINCT tags.id) = ?", len(names))
+	}
+
+	return db.Where("id IN (?)", sub)
+}
+
+// filterByPhonetic requires every word of search to sound like the first or
+// last name. Double Metaphone codes are compared in both directions between
+// primary and alternate; Soundex catches spellings Metaphone codes apart.
+func (repository *addressRepository) filterByPhonetic(db *gorm.DB, search string) *gorm.DB {
+	for _, word := range strings.Fields(search) {
+		condition, args := phoneticCondition(word)
+		db = db.Where(condition, args...)
+	}
+
+	return db
+}
+
+// phoneticCondition matches names that share a code with word. Only its
+// non-empty codes are compared, as names in other scripts, and some Latin
+// ones like "H", have no Metaphone code and would otherwise all match each
+// other. A word with no code at all, such as one in another script when
+// transliteration is off, falls back to matching the name key.
+func phoneticCondition(word string) (string, []interface{}) {
+	codes := utils.NamePhoneticCodes(word)
+
+	metaphones := []string{}
+	for _, code := range []string{codes.Metaphone, codes.MetaphoneAlternate} {
+		if code != "" {
+			metaphones = append(metaphones, code)
+		}
+	}
+
+	parts := []string{}
+	args := []interface{}{}
 
-	return addresses, total, err
-}
\ No newline at end of file
+	if len(metaphones) > 0 {
+		parts = append(parts,
+			"addresses.first_name_metaphone IN ?", "addresses.first_name_metaphone_alt IN ?",
+			"addresses.last_name_metaphone IN ?", "addresses.last_name_metaphone_alt IN ?")
+		args = append(args, metaphones, metaphones, metaphones, metaphones)
+	}
+
+	if codes.Soundex != "" {
+		parts = append(parts, "addresses.first_name_soundex = ?", "addresses.last_name_soundex = ?")
+		args = append(args, codes.Soundex, codes.Soundex)
+	}
+
+	if len(parts) == 0 {
+		return "addresses.name_key LIKE ?", []interface{}{"%" + utils.SearchKey(word) + "%"}
+	}
+
+	return "(" + strings.Join(parts, " OR ") + ")", args
+}
+
+func (repository *addressRepository) groupMembers(userId, groupId uint64, includeSubgroups bool) *gorm.DB {
+	sub := repository.db.Table("address_groups").Select("address_groups.address_id")
+
+	if includeSubgroups {
+		return sub.Where("address_groups.contact_group_id IN (?)", gorm.Expr(subgroupIDsQuery, groupId, userId))
+	}
+
+	return sub.Where("address_groups.contact_group_id = ?", groupId)
+}
+
+func (repository *addressRepository) FindByUserAndGroup(userID, groupID uint64, includeSubgroups bool) ([]model.Address, error) {
+	var addresses []model.Address
+
+	err := withContactDetails(repository.db).Preload("Tags").
+		Where("user_id = ? AND is_deleted = false", userID).
+		Where("id IN (?)", repository.groupMembers(userID, groupID, includeSubgroups)).
+		Find(&addresses).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+func (repository *addressRepository) filterByEvents(db *gorm.DB, eventType string, monthDays *dto.MonthDayRange) *gorm.DB {
+	sub := repository.db.Table("contact_events").
+		Select("1").
+		Where("contact_events.address_id = addresses.id")
+
+	if eventType != "" {
+		sub = sub.Where("contact_events.type = ?", eventType)
+	}
+
+	if monthDays != nil && !monthDays.All {
+		monthDay := "(contact_events.month * 100 + contact_events.day)"
+
+		if monthDays.From <= monthDays.To {
+			sub = sub.Where(monthDay+" BETWEEN ? AND ?", monthDays.From, monthDays.To)
+		} else {
+			sub = sub.Where("("+monthDay+" >= ? OR "+monthDay+" <= ?)", monthDays.From, monthDays.To)
+		}
+	}
+
+	return db.Where("EXISTS (?)", sub)
+}
+
+func (repository *addressRepository) FindWithEvents(userID uint64) ([]model.Address, error) {
+	var addresses []model.Address
+
+	err := repository.db.Preload("Events").
+		Where("user_id = ? AND is_deleted = false", userID).
+		Where("EXISTS (SELECT 1 FROM contact_events WHERE contact_events.address_id = addresses.id)").
+		Find(&addresses).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+func (repository *addressRepository) CountByIDsAndUser(ids []uint64, userID uint64) (int64, error) {
+	var count int64
+
+	err := repository.db.Model(&model.Address{}).Where("id IN ? AND user_id = ? AND is_deleted = false", ids, userID).Count(&count).Error
+
+	return count, err
+}
+func primaryFirst(db *gorm.DB) *gorm.DB {
+	return db.Order("is_primary DESC, id ASC")
+}
+
+func withContactDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Emails", primaryFirst).
+		Preload("Phones", primaryFirst).
+		Preload("PostalAddresses", primaryFirst).
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("month ASC, day ASC")
+		}).
+		Preload("CustomFieldValues.Field")
+}
+
+func replaceChildren(tx *gorm.DB, address *model.Address) error {
+	if err := tx.Where("address_id = ?", address.ID).Delete(&model.AddressEmail{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("address_id = ?", address.ID).Delete(&model.AddressPhone{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("address_id = ?", address.ID).Delete(&model.PostalAddress{}).Error; err != nil {
+		return err
+	}
+
+	for i := range address.Emails {
+		address.Emails[i].ID = 0
+		address.Emails[i].AddressID = address.ID
+	}
+	for i := range address.Phones {
+		address.Phones[i].ID = 0
+		address.Phones[i].AddressID = address.ID
+	}
+	for i := range address.PostalAddresses {
+		address.PostalAddresses[i].ID = 0
+		address.PostalAddresses[i].AddressID = address.ID
+	}
+
+	if len(address.Emails) > 0 {
+		if err := tx.Create(&address.Emails).Error; err != nil {
+			return err
+		}
+	}
+	if len(address.Phones) > 0 {
+		if err := tx.Create(&address.Phones).Error; err != nil {
+			return err
+		}
+	}
+	if len(address.PostalAddresses) > 0 {
+		if err := tx.Create(&address.PostalAddresses).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("address_id = ?", address.ID).Delete(&model.ContactEvent{}).Error; err != nil {
+		return err
+	}
+
+	for i := range address.Events {
+		address.Events[i].ID = 0
+		address.Events[i].AddressID = address.ID
+	}
+
+	if len(address.Events) > 0 {
+		if err := tx.Create(&address.Events).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("address_id = ?", address.ID).Delete(&model.CustomFieldValue{}).Error; err != nil {
+		return err
+	}
+
+	for i := range address.CustomFieldValues {
+		address.CustomFieldValues[i].ID = 0
+		address.CustomFieldValues[i].AddressID = address.ID
+	}
+
+	if len(address.CustomFieldValues) > 0 {
+		if err := tx.Omit("Field").Create(&address.CustomFieldValues).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repository *addressRepository) FindByIDsAndUser(ids []uint64, userID uint64) ([]model.Address, error) {
+	var addresses []model.Address
+
+	err := withContactDetails(repository.db).Preload("Tags").Preload("Groups").
+		Where("id IN ? AND user_id = ? AND is_deleted = false", ids, userID).
+		Order("id ASC").
+		Find(&addresses).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+func (repository *addressRepository) MoveToBook(ids []uint64, userID, bookID uint64) error {
+	return repository.db.Model(&model.Address{}).
+		Where("id IN ? AND user_id = ? AND is_deleted = false", ids, userID).
+		Update("book_id", bookID).Error
+}
+
+// CopyAll inserts the given addresses together with their child rows, tags
+// and groups in one transaction. Callers are expected to have cleared the
+// primary keys.
+func (repository *addressRepository) CopyAll(addresses []model.Address) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		for i := range addresses {
+			if err := tx.Omit("CustomFieldValues.Field").Create(&addresses[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (repository *addressRepository) FindByID(id uint64) (*model.Address, error) {
+	var address model.Address
+
+	err := repository.db.Where("id = ? AND is_deleted = false", id).First(&address).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+// UpdateGeocode stores the geocoding columns only. updated_at is left alone
+// since the contact itself did not change.
+func (repository *addressRepository) UpdateGeocode(address *model.Address) error {
+	return repository.db.Model(address).
+		Select("latitude", "longitude", "geocode_status", "geocode_accuracy", "geocoded_at").
+		UpdateColumns(address).Error
+}
+
+func (repository *addressRepository) FindPendingGeocodeIDs() ([]uint64, error) {
+	var ids []uint64
+
+	err := repository.db.Model(&model.Address{}).
+		Where("geocode_status = ? AND is_deleted = false", model.GeocodePending).
+		Order("id").
+		Pluck("id", &ids).Error
+
+	return ids, err
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(attachment *model.Attachment) error
+	FindByAddress(addressID, userID uint64) ([]model.Attachment, error)
+	FindByIDAndAddress(id, addressID, userID uint64) (*model.Attachment, error)
+	Update(attachment *model.Attachment) error
+	SoftDelete(id, addressID, userID uint64) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (repository *attachmentRepository) Create(attachment *model.Attachment) error {
+	return repository.db.Omit("Address").Create(attachment).Error
+}
+
+func (repository *attachmentRepository) FindByAddress(addressID, userID uint64) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+
+	err := repository.db.
+		Where("address_id = ? AND user_id = ? AND is_deleted = false", addressID, userID).
+		Order("created_at DESC").
+		Find(&attachments).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (repository *attachmentRepository) FindByIDAndAddress(id, addressID, userID uint64) (*model.Attachment, error) {
+	var attachment model.Attachment
+
+	err := repository.db.Where("id = ? AND address_id = ? AND user_id = ? AND is_deleted = false", id, addressID, userID).First(&attachment).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (repository *attachmentRepository) Update(attachment *model.Attachment) error {
+	return repository.db.Omit("Address").Save(attachment).Error
+}
+
+func (repository *attachmentRepository) SoftDelete(id, addressID, userID uint64) error {
+	return repository.db.Model(&model.Attachment{}).
+		Where("id = ? AND address_id = ? AND user_id = ?", id, addressID, userID).
+		Update("is_deleted", true).Error
+}
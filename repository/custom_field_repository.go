@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"gorm.io/gorm"
+)
+
+type CustomFieldRepository interface {
+	Create(field *model.CustomField) error
+	FindByUser(userID uint64) ([]model.CustomField, error)
+	FindByIDAndUser(id, userID uint64) (*model.CustomField, error)
+	FindByKeyAndUser(key string, userID uint64) (*model.CustomField, error)
+	Update(field *model.CustomField) error
+	Delete(id, userID uint64) error
+}
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db: db}
+}
+
+func (repository *customFieldRepository) Create(field *model.CustomField) error {
+	return repository.db.Create(field).Error
+}
+
+func (repository *customFieldRepository) FindByUser(userID uint64) ([]model.CustomField, error) {
+	var fields []model.CustomField
+
+	err := repository.db.Where("user_id = ?", userID).Order("id ASC").Find(&fields).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (repository *customFieldRepository) FindByIDAndUser(id, userID uint64) (*model.CustomField, error) {
+	var field model.CustomField
+
+	err := repository.db.Where("id = ? AND user_id = ?", id, userID).First(&field).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+func (repository *customFieldRepository) FindByKeyAndUser(key string, userID uint64) (*model.CustomField, error) {
+	var field model.CustomField
+
+	err := repository.db.Where("key = ? AND user_id = ?", key, userID).First(&field).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+func (repository *customFieldRepository) Update(field *model.CustomField) error {
+	return repository.db.Save(field).Error
+}
+
+func (repository *customFieldRepository) Delete(id, userID uint64) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&model.CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&model.CustomField{}).Error
+	})
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const subgroupIDsQuery = `WITH RECURSIVE subgroups AS (
+	SELECT id FROM contact_groups WHERE id = ? AND user_id = ?
+	UNION
+	SELECT g.id FROM contact_groups g JOIN subgroups s ON g.parent_id = s.id
+) SELECT id FROM subgroups`
+
+type GroupRepository interface {
+	Create(group *model.ContactGroup) error
+	FindByUser(userID uint64) ([]model.ContactGroup, error)
+	FindByIDAndUser(id, userID uint64) (*model.ContactGroup, error)
+	FindSubtreeIDs(id, userID uint64) ([]uint64, error)
+	Update(group *model.ContactGroup) error
+	Delete(group *model.ContactGroup) error
+	AddMembers(groupID uint64, addressIDs []uint64) error
+	RemoveMembers(groupID uint64, addressIDs []uint64) error
+}
+
+type groupRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupRepository(db *gorm.DB) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+func (repository *groupRepository) Create(group *model.ContactGroup) error {
+	return repository.db.Create(group).Error
+}
+
+func (repository *groupRepository) FindByUser(userID uint64) ([]model.ContactGroup, error) {
+	var groups []model.ContactGroup
+
+	err := repository.db.Where("user_id = ?", userID).Order("name ASC").Find(&groups).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (repository *groupRepository) FindByIDAndUser(id, userID uint64) (*model.ContactGroup, error) {
+	var group model.ContactGroup
+
+	err := repository.db.Where("id = ? AND user_id = ?", id, userID).First(&group).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (repository *groupRepository) FindSubtreeIDs(id, userID uint64) ([]uint64, error) {
+	var ids []uint64
+
+	err := repository.db.Raw(subgroupIDsQuery, id, userID).Scan(&ids).Error
+
+	return ids, err
+}
+
+func (repository *groupRepository) Update(group *model.ContactGroup) error {
+	return repository.db.Save(group).Error
+}
+
+// Delete removes the group and its memberships. Subgroups are re-attached to
+// the deleted group's parent so nested contacts are not orphaned.
+func (repository *groupRepository) Delete(group *model.ContactGroup) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.ContactGroup{}).
+			Where("parent_id = ? AND user_id = ?", group.ID, group.UserID).
+			Update("parent_id", group.ParentID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM address_groups WHERE contact_group_id = ?", group.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(group).Error
+	})
+}
+
+func (repository *groupRepository) AddMembers(groupID uint64, addressIDs []uint64) error {
+	rows := make([]map[string]interface{}, 0, len(addressIDs))
+
+	for _, addressID := range addressIDs {
+		rows = append(rows, map[string]interface{}{
+			"address_id":       addressID,
+			"contact_group_id": groupID,
+		})
+	}
+
+	return repository.db.Table("address_groups").Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (repository *groupRepository) RemoveMembers(groupID uint64, addressIDs []uint64) error {
+	return repository.db.Exec(
+		"DELETE FROM address_groups WHERE contact_group_id = ? AND address_id IN ?",
+		groupID,
+		addressIDs,
+	).Error
+}
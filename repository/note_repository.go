@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"gorm.io/gorm"
+)
+
+type NoteRepository interface {
+	Create(note *model.Note) error
+	FindByAddress(addressID, userID uint64) ([]model.Note, error)
+	FindByIDAndAddress(id, addressID, userID uint64) (*model.Note, error)
+	Update(note *model.Note) error
+	SoftDelete(id, addressID, userID uint64) error
+}
+
+type noteRepository struct {
+	db *gorm.DB
+}
+
+func NewNoteRepository(db *gorm.DB) NoteRepository {
+	return &noteRepository{db: db}
+}
+
+func (repository *noteRepository) Create(note *model.Note) error {
+	return repository.db.Omit("Address").Create(note).Error
+}
+
+func (repository *noteRepository) FindByAddress(addressID, userID uint64) ([]model.Note, error) {
+	var notes []model.Note
+
+	err := repository.db.
+		Where("address_id = ? AND user_id = ? AND is_deleted = false", addressID, userID).
+		Order("created_at DESC").
+		Find(&notes).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+func (repository *noteRepository) FindByIDAndAddress(id, addressID, userID uint64) (*model.Note, error) {
+	var note model.Note
+
+	err := repository.db.Where("id = ? AND address_id = ? AND user_id = ? AND is_deleted = false", id, addressID, userID).First(&note).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+func (repository *noteRepository) Update(note *model.Note) error {
+	return repository.db.Omit("Address").Save(note).Error
+}
+
+func (repository *noteRepository) SoftDelete(id, addressID, userID uint64) error {
+	return repository.db.Model(&model.Note{}).
+		Where("id = ? AND address_id = ? AND user_id = ?", id, addressID, userID).
+		Update("is_deleted", true).Error
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository interface {
+	// Create stores the organization together with its account user and
+	// the creator's owner membership.
+	Create(org *model.Organization, account *model.User, owner *model.OrganizationMember) error
+	FindByID(id uint64) (*model.Organization, error)
+	FindMembershipsByUser(userID uint64) ([]model.OrganizationMember, error)
+	FindMember(orgID, userID uint64) (*model.OrganizationMember, error)
+	FindMemberByID(id, orgID uint64) (*model.OrganizationMember, error)
+	FindMembers(orgID uint64) ([]model.OrganizationMember, error)
+	UpdateMember(member *model.OrganizationMember) error
+	DeleteMember(id, orgID uint64) error
+	CreateInvite(invite *model.OrganizationInvite) error
+	FindInviteByID(id, orgID uint64) (*model.OrganizationInvite, error)
+	FindInvites(orgID uint64) ([]model.OrganizationInvite, error)
+	FindPendingInviteByEmail(orgID uint64, email string) (*model.OrganizationInvite, error)
+	FindPendingInviteByTokenHash(hash string) (*model.OrganizationInvite, error)
+	FindReceivedInvites(email string) ([]model.OrganizationInvite, error)
+	UpdateInvite(invite *model.OrganizationInvite) error
+	// AcceptInvite marks the invite accepted and adds the member in one
+	// transaction.
+	AcceptInvite(invite *model.OrganizationInvite, member *model.OrganizationMember) error
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (repository *organizationRepository) Create(org *model.Organization, account *model.User, owner *model.OrganizationMember) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+
+		org.AccountID = account.ID
+		if err := tx.Omit("Account").Create(org).Error; err != nil {
+			return err
+		}
+
+		owner.OrganizationID = org.ID
+		return tx.Omit("Organization", "User").Create(owner).Error
+	})
+}
+
+func (repository *organizationRepository) FindByID(id uint64) (*model.Organization, error) {
+	var org model.Organization
+
+	err := repository.db.Where("id = ?", id).First(&org).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (repository *organizationRepository) FindMembershipsByUser(userID uint64) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+
+	err := repository.db.Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&members).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (repository *organizationRepository) FindMember(orgID, userID uint64) (*model.OrganizationMember, error) {
+	var member model.OrganizationMember
+
+	err := repository.db.Preload("Organization").
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		First(&member).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (repository *organizationRepository) FindMemberByID(id, orgID uint64) (*model.OrganizationMember, error) {
+	var member model.OrganizationMember
+
+	err := repository.db.Where("id = ? AND organization_id = ?", id, orgID).First(&member).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (repository *organizationRepository) FindMembers(orgID uint64) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+
+	err := repository.db.Preload("User").
+		Where("organization_id = ?", orgID).
+		Order("created_at ASC").
+		Find(&members).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (repository *organizationRepository) UpdateMember(member *model.OrganizationMember) error {
+	return repository.db.Omit("Organization", "User").Save(member).Error
+}
+
+func (repository *organizationRepository) DeleteMember(id, orgID uint64) error {
+	return repository.db.Where("id = ? AND organization_id = ?", id, orgID).Delete(&model.OrganizationMember{}).Error
+}
+
+func (repository *organizationRepository) CreateInvite(invite *model.OrganizationInvite) error {
+	return repository.db.Omit("Organization").Create(invite).Error
+}
+
+func (repository *organizationRepository) FindInviteByID(id, orgID uint64) (*model.OrganizationInvite, error) {
+	var invite model.OrganizationInvite
+
+	err := repository.db.
+		Where("id = ? AND organization_id = ? AND status = ?", id, orgID, model.ShareStatusPending).
+		First(&invite).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (repository *organizationRepository) FindInvites(orgID uint64) ([]model.OrganizationInvite, error) {
+	var invites []model.OrganizationInvite
+
+	err := repository.db.
+		Where("organization_id = ? AND status = ? AND expires_at > ?", orgID, model.ShareStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (repository *organizationRepository) FindPendingInviteByEmail(orgID uint64, email string) (*model.OrganizationInvite, error) {
+	var invite model.OrganizationInvite
+
+	err := repository.db.
+		Where("organization_id = ? AND LOWER(email) = LOWER(?) AND status = ? AND expires_at > ?",
+			orgID, email, model.ShareStatusPending, time.Now()).
+		First(&invite).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (repository *organizationRepository) FindPendingInviteByTokenHash(hash string) (*model.OrganizationInvite, error) {
+	var invite model.OrganizationInvite
+
+	err := repository.db.Preload("Organization").
+		Where("token_hash = ? AND status = ? AND expires_at > ?", hash, model.ShareStatusPending, time.Now()).
+		First(&invite).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (repository *organizationRepository) FindReceivedInvites(email string) ([]model.OrganizationInvite, error) {
+	var invites []model.OrganizationInvite
+
+	err := repository.db.Preload("Organization").
+		Where("LOWER(email) = LOWER(?) AND status = ? AND expires_at > ?", email, model.ShareStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (repository *organizationRepository) UpdateInvite(invite *model.OrganizationInvite) error {
+	return repository.db.Omit("Organization").Save(invite).Error
+}
+
+func (repository *organizationRepository) AcceptInvite(invite *model.OrganizationInvite, member *model.OrganizationMember) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Organization").Save(invite).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Organization", "User").Create(member).Error
+	})
+}
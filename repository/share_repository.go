@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShareRepository interface {
+	Create(share *model.AddressBookShare) error
+	FindByIDAndOwner(id, ownerID uint64) (*model.AddressBookShare, error)
+	FindByOwner(ownerID uint64) ([]model.AddressBookShare, error)
+	FindAccepted(ownerID, granteeID uint64) (*model.AddressBookShare, error)
+	FindActiveByOwnerAndEmail(ownerID uint64, email string) (*model.AddressBookShare, error)
+	FindPendingByTokenHash(hash string) (*model.AddressBookShare, error)
+	FindReceived(granteeID uint64, email string) ([]model.AddressBookShare, error)
+	Update(share *model.AddressBookShare) error
+}
+
+type shareRepository struct {
+	db *gorm.DB
+}
+
+func NewShareRepository(db *gorm.DB) ShareRepository {
+	return &shareRepository{db: db}
+}
+
+func (repository *shareRepository) Create(share *model.AddressBookShare) error {
+	return repository.db.Omit("Owner").Create(share).Error
+}
+
+func (repository *shareRepository) FindByIDAndOwner(id, ownerID uint64) (*model.AddressBookShare, error) {
+	var share model.AddressBookShare
+
+	err := repository.db.Where("id = ? AND owner_id = ? AND status <> ?", id, ownerID, model.ShareStatusRevoked).First(&share).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (repository *shareRepository) FindByOwner(ownerID uint64) ([]model.AddressBookShare, error) {
+	var shares []model.AddressBookShare
+
+	err := repository.db.
+		Where("owner_id = ? AND status <> ?", ownerID, model.ShareStatusRevoked).
+		Order("created_at DESC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+func (repository *shareRepository) FindAccepted(ownerID, granteeID uint64) (*model.AddressBookShare, error) {
+	var share model.AddressBookShare
+
+	err := repository.db.
+		Where("owner_id = ? AND grantee_id = ? AND status = ?", ownerID, granteeID, model.ShareStatusAccepted).
+		First(&share).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (repository *shareRepository) FindActiveByOwnerAndEmail(ownerID uint64, email string) (*model.AddressBookShare, error) {
+	var share model.AddressBookShare
+
+	err := repository.db.
+		Where("owner_id = ? AND LOWER(invitee_email) = LOWER(?) AND status IN ?", ownerID, email,
+			[]string{model.ShareStatusPending, model.ShareStatusAccepted}).
+		Where("status = ? OR expires_at > ?", model.ShareStatusAccepted, time.Now()).
+		First(&share).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (repository *shareRepository) FindPendingByTokenHash(hash string) (*model.AddressBookShare, error) {
+	var share model.AddressBookShare
+
+	err := repository.db.
+		Where("token_hash = ? AND status = ? AND expires_at > ?", hash, model.ShareStatusPending, time.Now()).
+		First(&share).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (repository *shareRepository) FindReceived(granteeID uint64, email string) ([]model.AddressBookShare, error) {
+	var shares []model.AddressBookShare
+
+	err := repository.db.Preload("Owner").
+		Where("(grantee_id = ? AND status = ?) OR (LOWER(invitee_email) = LOWER(?) AND status = ? AND expires_at > ?)",
+			granteeID, model.ShareStatusAccepted, email, model.ShareStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+func (repository *shareRepository) Update(share *model.AddressBookShare) error {
+	return repository.db.Omit("Owner").Save(share).Error
+}
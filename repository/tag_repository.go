@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"address-book-server/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository interface {
+	Create(tag *model.Tag) error
+	FindByUser(userID uint64) ([]model.Tag, error)
+	FindByIDAndUser(id, userID uint64) (*model.Tag, error)
+	FindByNameAndUser(name string, userID uint64) (*model.Tag, error)
+	CountByIDsAndUser(ids []uint64, userID uint64) (int64, error)
+	Update(tag *model.Tag) error
+	Delete(id, userID uint64) error
+	Assign(tagIDs, addressIDs []uint64) error
+	Unassign(tagIDs, addressIDs []uint64) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (repository *tagRepository) Create(tag *model.Tag) error {
+	return repository.db.Create(tag).Error
+}
+
+func (repository *tagRepository) FindByUser(userID uint64) ([]model.Tag, error) {
+	var tags []model.Tag
+
+	err := repository.db.Where("user_id = ?", userID).Order("name ASC").Find(&tags).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (repository *tagRepository) FindByIDAndUser(id, userID uint64) (*model.Tag, error) {
+	var tag model.Tag
+
+	err := repository.db.Where("id = ? AND user_id = ?", id, userID).First(&tag).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (repository *tagRepository) FindByNameAndUser(name string, userID uint64) (*model.Tag, error) {
+	var tag model.Tag
+
+	err := repository.db.Where("LOWER(name) = LOWER(?) AND user_id = ?", name, userID).First(&tag).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (repository *tagRepository) CountByIDsAndUser(ids []uint64, userID uint64) (int64, error) {
+	var count int64
+
+	err := repository.db.Model(&model.Tag{}).Where("id IN ? AND user_id = ?", ids, userID).Count(&count).Error
+
+	return count, err
+}
+
+func (repository *tagRepository) Update(tag *model.Tag) error {
+	return repository.db.Save(tag).Error
+}
+
+func (repository *tagRepository) Delete(id, userID uint64) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM address_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Tag{}).Error
+	})
+}
+
+// assignBatchSize bounds the rows, and so the bind parameters, of each
+// INSERT into address_tags.
+const assignBatchSize = 500
+
+func (repository *tagRepository) Assign(tagIDs, addressIDs []uint64) error {
+	rows := make([]map[string]interface{}, 0, len(tagIDs)*len(addressIDs))
+
+	for _, addressID := range addressIDs {
+		for _, tagID := range tagIDs {
+			rows = append(rows, map[string]interface{}{
+				"address_id": addressID,
+				"tag_id":     tagID,
+			})
+		}
+	}
+
+	return repository.db.Table("address_tags").Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&rows, assignBatchSize).Error
+}
+
+func (repository *tagRepository) Unassign(tagIDs, addressIDs []uint64) error {
+	return repository.db.Exec(
+		"DELETE FROM address_tags WHERE tag_id IN ? AND address_id IN ?",
+		tagIDs,
+		addressIDs,
+	).Error
+}
@@ -9,6 +9,9 @@ import (
 type UserRepository interface {
 	Create(user *model.User) error
 	FindByEmail(email string) (*model.User, error)
+	FindByID(id uint64) (*model.User, error)
+	FindByCalendarTokenHash(hash string) (*model.User, error)
+	UpdateCalendarTokenHash(userID uint64, hash *string) error
 }
 
 type userRepository struct {
@@ -35,4 +38,32 @@ func (repo *userRepository) FindByEmail(email string) (*model.User, error) {
 	}
 
 	return &user, nil
-}
\ No newline at end of file
+}
+
+func (repo *userRepository) FindByCalendarTokenHash(hash string) (*model.User, error) {
+	var user model.User
+
+	err := repo.db.Where("calendar_token_hash = ? AND is_deleted = false", hash).First(&user).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repo *userRepository) UpdateCalendarTokenHash(userID uint64, hash *string) error {
+	return repo.db.Model(&model.User{}).Where("id = ?", userID).Update("calendar_token_hash", hash).Error
+}
+
+func (repo *userRepository) FindByID(id uint64) (*model.User, error) {
+	var user model.User
+
+	err := repo.db.Where("id = ? AND is_deleted = false", id).First(&user).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
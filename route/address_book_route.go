@@ -0,0 +1,20 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+	"address-book-server/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AddressBookRoute(router *gin.Engine, bookController controller.AddressBookController, access middleware.AccessResolver, books middleware.BookResolver) {
+	bookApi := router.Group("/api/v1/books")
+	bookApi.Use(middleware.AuthMiddleware())
+	{
+		bookApi.GET("/", middleware.AddressBookAccess(access, model.RoleViewer), bookController.List)
+		bookApi.POST("/", middleware.AddressBookAccess(access, model.RoleManager), bookController.Create)
+		bookApi.PUT("/:bookId", middleware.AddressBookScope(books, access, model.RoleManager), bookController.Update)
+		bookApi.DELETE("/:bookId", middleware.AddressBookScope(books, access, model.RoleManager), bookController.Delete)
+	}
+}
@@ -2,19 +2,53 @@ package route
 
 import (
 	"address-book-server/controller"
-	"address-book-server/middleware"	
+	"address-book-server/middleware"
+	"address-book-server/model"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AddressRoute(router *gin.Engine, addressController controller.AddressController) {
+// AddressRoute serves contacts, with their photos, notes and attachments,
+// both under /api/v1/books/:bookId/addresses and, for the caller's (or
+// owner_id's) default book, under /api/v1/address.
+func AddressRoute(router *gin.Engine, addressController controller.AddressController, photoController controller.PhotoController, noteController controller.NoteController, attachmentController controller.AttachmentController, access middleware.AccessResolver, books middleware.BookResolver) {
 	addressApi := router.Group("/api/v1/address")
 	addressApi.Use(middleware.AuthMiddleware())
-	{
-		addressApi.GET("/", addressController.List)
-		addressApi.POST("/", addressController.Create)
-		addressApi.PUT("/:id", addressController.Update)
-		addressApi.DELETE("/:id", addressController.Delete)
-		addressApi.POST("/export", addressController.Export)
-	}
+	registerAddressRoutes(addressApi, addressController, photoController, noteController, attachmentController, access, books)
+
+	bookAddressApi := router.Group("/api/v1/books/:bookId/addresses")
+	bookAddressApi.Use(middleware.AuthMiddleware())
+	registerAddressRoutes(bookAddressApi, addressController, photoController, noteController, attachmentController, access, books)
+}
+
+func registerAddressRoutes(group *gin.RouterGroup, addressController controller.AddressController, photoController controller.PhotoController, noteController controller.NoteController, attachmentController controller.AttachmentController, access middleware.AccessResolver, books middleware.BookResolver) {
+	canView := middleware.AddressBookScope(books, access, model.RoleViewer)
+	canEdit := middleware.AddressBookScope(books, access, model.RoleEditor)
+
+	group.GET("/", canView, addressController.List)
+	group.POST("/", canEdit, addressController.Create)
+	group.GET("/:id", canView, addressController.Get)
+	group.PUT("/:id", canEdit, addressController.Update)
+	group.DELETE("/:id", canEdit, addressController.Delete)
+	group.POST("/export", canView, addressController.Export)
+	group.GET("/upcoming", canView, addressController.Upcoming)
+	group.POST("/move", canEdit, addressController.Move)
+	group.POST("/copy", canEdit, addressController.Copy)
+
+	group.POST("/:id/geocode", canEdit, addressController.Regeocode)
+
+	group.PUT("/:id/photo", canEdit, photoController.Upload)
+	group.GET("/:id/photo", canView, photoController.Get)
+	group.DELETE("/:id/photo", canEdit, photoController.Delete)
+
+	group.GET("/:id/notes", canView, noteController.List)
+	group.POST("/:id/notes", canEdit, noteController.Create)
+	group.PUT("/:id/notes/:noteId", canEdit, noteController.Update)
+	group.DELETE("/:id/notes/:noteId", canEdit, noteController.Delete)
+
+	group.GET("/:id/attachments", canView, attachmentController.List)
+	group.POST("/:id/attachments", canEdit, attachmentController.Upload)
+	group.GET("/:id/attachments/:attachmentId", canView, attachmentController.Download)
+	group.PUT("/:id/attachments/:attachmentId", canEdit, attachmentController.Update)
+	group.DELETE("/:id/attachments/:attachmentId", canEdit, attachmentController.Delete)
 }
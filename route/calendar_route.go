@@ -0,0 +1,20 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CalendarRoute(router *gin.Engine, calendarController controller.CalendarController) {
+	calendarApi := router.Group("/api/v1/calendar")
+	{
+		// The feed is authenticated by the secret token in its URL, since
+		// calendar apps cannot send bearer tokens.
+		calendarApi.GET("/feed/:token", calendarController.Feed)
+
+		calendarApi.POST("/token", middleware.AuthMiddleware(), calendarController.RegenerateToken)
+		calendarApi.DELETE("/token", middleware.AuthMiddleware(), calendarController.RevokeToken)
+	}
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+	"address-book-server/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CustomFieldRoute(router *gin.Engine, customFieldController controller.CustomFieldController, access middleware.AccessResolver) {
+	canView := middleware.AddressBookAccess(access, model.RoleViewer)
+	canManage := middleware.AddressBookAccess(access, model.RoleManager)
+
+	customFieldApi := router.Group("/api/v1/custom-fields")
+	customFieldApi.Use(middleware.AuthMiddleware())
+	{
+		customFieldApi.GET("/", canView, customFieldController.List)
+		customFieldApi.POST("/", canManage, customFieldController.Create)
+		customFieldApi.PUT("/:id", canManage, customFieldController.Update)
+		customFieldApi.DELETE("/:id", canManage, customFieldController.Delete)
+	}
+}
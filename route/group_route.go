@@ -0,0 +1,26 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+	"address-book-server/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GroupRoute(router *gin.Engine, groupController controller.GroupController, access middleware.AccessResolver) {
+	canView := middleware.AddressBookAccess(access, model.RoleViewer)
+	canEdit := middleware.AddressBookAccess(access, model.RoleEditor)
+	canManage := middleware.AddressBookAccess(access, model.RoleManager)
+
+	groupApi := router.Group("/api/v1/groups")
+	groupApi.Use(middleware.AuthMiddleware())
+	{
+		groupApi.GET("/", canView, groupController.List)
+		groupApi.POST("/", canManage, groupController.Create)
+		groupApi.PUT("/:id", canManage, groupController.Update)
+		groupApi.DELETE("/:id", canManage, groupController.Delete)
+		groupApi.POST("/:id/members", canEdit, groupController.AddMembers)
+		groupApi.DELETE("/:id/members", canEdit, groupController.RemoveMembers)
+	}
+}
@@ -0,0 +1,34 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+	"address-book-server/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationRoute serves organization management. Member and invite
+// endpoints act on the organization named in the X-Organization-ID header.
+func OrganizationRoute(router *gin.Engine, organizationController controller.OrganizationController) {
+	isMember := middleware.OrganizationRole(model.OrgRoleMember)
+	isAdmin := middleware.OrganizationRole(model.OrgRoleAdmin)
+
+	organizationApi := router.Group("/api/v1/organizations")
+	organizationApi.Use(middleware.AuthMiddleware())
+	{
+		organizationApi.GET("/", organizationController.List)
+		organizationApi.POST("/", organizationController.Create)
+
+		organizationApi.GET("/members", isMember, organizationController.Members)
+		organizationApi.PUT("/members/:memberId", isAdmin, organizationController.UpdateMember)
+		organizationApi.DELETE("/members/:memberId", isMember, organizationController.RemoveMember)
+
+		organizationApi.GET("/invites", isAdmin, organizationController.Invites)
+		organizationApi.POST("/invites", isAdmin, organizationController.Invite)
+		organizationApi.DELETE("/invites/:inviteId", isAdmin, organizationController.RevokeInvite)
+
+		organizationApi.GET("/invites/received", organizationController.ReceivedInvites)
+		organizationApi.POST("/invites/accept", organizationController.AcceptInvite)
+	}
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ReferenceRoute(router *gin.Engine, referenceController controller.ReferenceController) {
+	referenceApi := router.Group("/api/v1/reference")
+	referenceApi.Use(middleware.AuthMiddleware())
+	{
+		// Looks up the bundled sample of postal codes unless POSTAL_CODES_FILE
+		// loads a full directory.
+		referenceApi.GET("/postal/:country/:code", referenceController.PostalCode)
+
+		// Which geocoder locates contacts and whether it covers more than
+		// the bundled sample.
+		referenceApi.GET("/geocoder", referenceController.Geocoder)
+	}
+}
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+	"address-book-server/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ShareRoute(router *gin.Engine, shareController controller.ShareController, access middleware.AccessResolver) {
+	canManage := middleware.AddressBookAccess(access, model.RoleManager)
+
+	shareApi := router.Group("/api/v1/shares")
+	shareApi.Use(middleware.AuthMiddleware())
+	{
+		shareApi.GET("/", canManage, shareController.List)
+		shareApi.POST("/", canManage, shareController.Invite)
+		shareApi.PUT("/:id", canManage, shareController.Update)
+		shareApi.DELETE("/:id", canManage, shareController.Revoke)
+
+		shareApi.GET("/received", shareController.Received)
+		shareApi.POST("/accept", shareController.Accept)
+	}
+}
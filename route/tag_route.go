@@ -0,0 +1,26 @@
+package route
+
+import (
+	"address-book-server/controller"
+	"address-book-server/middleware"
+	"address-book-server/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TagRoute(router *gin.Engine, tagController controller.TagController, access middleware.AccessResolver) {
+	canView := middleware.AddressBookAccess(access, model.RoleViewer)
+	canEdit := middleware.AddressBookAccess(access, model.RoleEditor)
+	canManage := middleware.AddressBookAccess(access, model.RoleManager)
+
+	tagApi := router.Group("/api/v1/tags")
+	tagApi.Use(middleware.AuthMiddleware())
+	{
+		tagApi.GET("/", canView, tagController.List)
+		tagApi.POST("/", canManage, tagController.Create)
+		tagApi.PUT("/:id", canManage, tagController.Update)
+		tagApi.DELETE("/:id", canManage, tagController.Delete)
+		tagApi.POST("/assign", canEdit, tagController.Assign)
+		tagApi.POST("/unassign", canEdit, tagController.Unassign)
+	}
+}
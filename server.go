@@ -2,14 +2,21 @@ package main
 
 import (
 	"address-book-server/controller"
+	"address-book-server/geocoding"
 	"address-book-server/logger"
 	"address-book-server/middleware"
+	"address-book-server/postal"
 	"address-book-server/repository"
 	"address-book-server/route"
 	"address-book-server/service"
+	"address-book-server/storage"
 	"address-book-server/utils"
 	"address-book-server/validator"
 
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
@@ -25,6 +32,10 @@ func main() {
 		logger.Log.Error("Error loading the .env file", zap.Error(err))
 	}
 
+	if err := postal.LoadConfiguredPostcodes(); err != nil {
+		logger.Log.Fatal("Failed to load postal codes", zap.Error(err))
+	}
+
 	db := utils.Connect()
 	utils.PerformMigration(db)
 
@@ -35,16 +46,122 @@ func main() {
 	authController := controller.NewAuthController(userService)
 
 	addressRepo := repository.NewAddressRepository(db)
-	addressService := service.NewAddressService(addressRepo)
+	groupRepo := repository.NewGroupRepository(db)
+	customFieldRepo := repository.NewCustomFieldRepository(db)
+	bookRepo := repository.NewAddressBookRepository(db)
+	bookService := service.NewAddressBookService(bookRepo)
+	bookController := controller.NewAddressBookController(bookService)
+
+	geocoder, err := newGeocoder()
+	if err != nil {
+		logger.Log.Fatal("Failed to initialise geocoder", zap.Error(err))
+	}
+	if geocoding.CoverageOf(geocoder) == geocoding.CoverageSample {
+		logger.Log.Warn("Geocoding with the bundled sample gazetteer; set GAZETTEER_FILE or GEOCODER=nominatim to locate other places")
+	}
+
+	geocodingService := service.NewGeocodingService(addressRepo, geocoder, geocoderWorkers())
+	go geocodingService.ResumePending()
+
+	addressService := service.NewAddressService(addressRepo, groupRepo, customFieldRepo, userRepo, bookRepo, geocodingService)
 	addressController := controller.NewAddressController(addressService)
 
+	blobDir := os.Getenv("BLOB_STORAGE_DIR")
+	if blobDir == "" {
+		blobDir = "uploads"
+	}
+
+	blobStore, err := storage.NewLocalBlobStore(blobDir)
+	if err != nil {
+		logger.Log.Fatal("Failed to initialise blob storage", zap.Error(err))
+	}
+
+	photoService := service.NewPhotoService(addressRepo, blobStore)
+	photoController := controller.NewPhotoController(photoService)
+
+	noteRepo := repository.NewNoteRepository(db)
+	noteService := service.NewNoteService(noteRepo, addressRepo)
+	noteController := controller.NewNoteController(noteService)
+
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	attachmentService := service.NewAttachmentService(attachmentRepo, addressRepo, blobStore)
+	attachmentController := controller.NewAttachmentController(attachmentService)
+
+	calendarService := service.NewCalendarService(userRepo, addressRepo)
+	calendarController := controller.NewCalendarController(calendarService)
+
+	shareRepo := repository.NewShareRepository(db)
+	shareService := service.NewShareService(shareRepo, userRepo)
+	shareController := controller.NewShareController(shareService)
+
+	organizationRepo := repository.NewOrganizationRepository(db)
+	organizationService := service.NewOrganizationService(organizationRepo, userRepo)
+	organizationController := controller.NewOrganizationController(organizationService)
+	middleware.UseOrganizations(organizationService)
+
+	tagRepo := repository.NewTagRepository(db)
+	tagService := service.NewTagService(tagRepo, addressRepo)
+	tagController := controller.NewTagController(tagService)
+
+	groupService := service.NewGroupService(groupRepo, addressRepo)
+	groupController := controller.NewGroupController(groupService)
+
+	customFieldService := service.NewCustomFieldService(customFieldRepo)
+	customFieldController := controller.NewCustomFieldController(customFieldService)
+
+	referenceService := service.NewReferenceService(geocoderName(), geocoding.CoverageOf(geocoder))
+	referenceController := controller.NewReferenceController(referenceService)
+
 	r := gin.New()
 	r.Use(middleware.ReuqestLogger())
 	r.Use(gin.Recovery())
 	r.Use(middleware.ErrorHandler())
 	
 	route.AuthRoute(r, authController)
-	route.AddressRoute(r, addressController)
+	route.AddressRoute(r, addressController, photoController, noteController, attachmentController, shareService, bookService)
+	route.AddressBookRoute(r, bookController, shareService, bookService)
+	route.TagRoute(r, tagController, shareService)
+	route.GroupRoute(r, groupController, shareService)
+	route.CustomFieldRoute(r, customFieldController, shareService)
+	route.CalendarRoute(r, calendarController)
+	route.ShareRoute(r, shareController, shareService)
+	route.OrganizationRoute(r, organizationController)
+	route.ReferenceRoute(r, referenceController)
 	
 	r.Run(":8080")
-}
\ No newline at end of file
+}
+
+// geocoderName is the provider named by GEOCODER, "offline" by default.
+func geocoderName() string {
+	if name := os.Getenv("GEOCODER"); name != "" {
+		return name
+	}
+	return "offline"
+}
+
+// newGeocoder picks the provider named by GEOCODER: "nominatim" for a
+// Nominatim search API at NOMINATIM_URL, or "offline" (the default) for the
+// gazetteer at GAZETTEER_FILE. Without one the offline geocoder only has the
+// bundled sample, which knows about a hundred places.
+func newGeocoder() (geocoding.Geocoder, error) {
+	switch geocoderName() {
+	case "nominatim":
+		return geocoding.NewNominatimGeocoder(
+			os.Getenv("NOMINATIM_URL"),
+			os.Getenv("NOMINATIM_USER_AGENT"),
+			os.Getenv("NOMINATIM_EMAIL"),
+		), nil
+	case "offline":
+		return geocoding.NewOfflineGeocoder(os.Getenv("GAZETTEER_FILE"))
+	}
+
+	return nil, fmt.Errorf("unknown GEOCODER %q", os.Getenv("GEOCODER"))
+}
+
+func geocoderWorkers() int {
+	workers, err := strconv.Atoi(os.Getenv("GEOCODER_WORKERS"))
+	if err != nil || workers < 1 {
+		return 1
+	}
+	return workers
+}
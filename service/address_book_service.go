@@ -0,0 +1,266 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/mapper"
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AddressBookService interface {
+	List(userId uint64) ([]dto.AddressBookResponse, error)
+	Create(userId uint64, req *dto.AddressBookRequest) (*dto.AddressBookResponse, error)
+	Update(id, userId uint64, req *dto.AddressBookRequest) error
+	Delete(id, userId uint64) error
+	// BookOwner and DefaultBook let the access middleware resolve which
+	// book, and therefore whose data, a request acts on.
+	BookOwner(bookId uint64) (uint64, error)
+	DefaultBook(ownerId uint64) (uint64, error)
+}
+
+type addressBookService struct {
+	repo repository.AddressBookRepository
+}
+
+func NewAddressBookService(repo repository.AddressBookRepository) AddressBookService {
+	return &addressBookService{repo: repo}
+}
+
+func (s *addressBookService) List(userId uint64) ([]dto.AddressBookResponse, error) {
+
+	if _, err := s.DefaultBook(userId); err != nil {
+		return nil, err
+	}
+
+	books, err := s.repo.FindByUser(userId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch address books",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch address books",
+			err,
+		)
+	}
+
+	response := make([]dto.AddressBookResponse, 0, len(books))
+	for _, b := range books {
+		response = append(response, mapper.ToAddressBookResponse(b))
+	}
+
+	return response, nil
+}
+
+func (s *addressBookService) Create(userId uint64, req *dto.AddressBookRequest) (*dto.AddressBookResponse, error) {
+
+	logger.Log.Info(
+		"Creating address book",
+		zap.Uint64("user_id", userId),
+		zap.String("name", req.Name),
+	)
+
+	// Make sure the default book exists first so a new book can never take
+	// its name.
+	if _, err := s.DefaultBook(userId); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+
+	if err := s.validateName(name, userId, 0); err != nil {
+		return nil, err
+	}
+
+	book := model.AddressBook{
+		UserID: userId,
+		Name:   name,
+	}
+
+	if err := s.repo.Create(&book); err != nil {
+
+		logger.Log.Error(
+			"Failed to create address book",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create address book",
+			err,
+		)
+	}
+
+	response := mapper.ToAddressBookResponse(book)
+
+	return &response, nil
+}
+
+func (s *addressBookService) Update(id, userId uint64, req *dto.AddressBookRequest) error {
+
+	logger.Log.Info(
+		"Renaming address book",
+		zap.Uint64("book_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	book, err := s.repo.FindByIDAndUser(id, userId)
+	if err != nil {
+		return appError.NotFound(
+			"Address book not found",
+			err,
+		)
+	}
+
+	name := strings.TrimSpace(req.Name)
+
+	if err := s.validateName(name, userId, book.ID); err != nil {
+		return err
+	}
+
+	book.Name = name
+
+	if err := s.repo.Update(book); err != nil {
+
+		logger.Log.Error(
+			"Failed to update address book",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to update address book",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *addressBookService) Delete(id, userId uint64) error {
+
+	logger.Log.Info(
+		"Deleting address book",
+		zap.Uint64("book_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	book, err := s.repo.FindByIDAndUser(id, userId)
+	if err != nil {
+		return appError.NotFound(
+			"Address book not found",
+			err,
+		)
+	}
+
+	if book.IsDefault {
+		return appError.BadRequest(
+			"The default address book cannot be deleted",
+			nil,
+		)
+	}
+
+	count, err := s.repo.CountAddresses(id)
+	if err != nil {
+		return appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	if count > 0 {
+		return appError.BadRequest(
+			"Address book is not empty; move or delete its contacts first",
+			nil,
+		)
+	}
+
+	if err := s.repo.Delete(id, userId); err != nil {
+
+		logger.Log.Error(
+			"Failed to delete address book",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to delete address book",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *addressBookService) BookOwner(bookId uint64) (uint64, error) {
+	book, err := s.repo.FindByID(bookId)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, appError.NotFound(
+			"Address book not found",
+			err,
+		)
+	}
+
+	if err != nil {
+		return 0, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	return book.UserID, nil
+}
+
+func (s *addressBookService) DefaultBook(ownerId uint64) (uint64, error) {
+	book, err := s.repo.FindOrCreateDefault(ownerId)
+
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to resolve default address book",
+			zap.Uint64("user_id", ownerId),
+			zap.String("error", err.Error()),
+		)
+
+		return 0, appError.Internal(
+			"Failed to resolve default address book",
+			err,
+		)
+	}
+
+	return book.ID, nil
+}
+
+func (s *addressBookService) validateName(name string, userId, exceptId uint64) error {
+	if name == "" {
+		return appError.NewValidationError(map[string]string{
+			"name": "This field is required",
+		})
+	}
+
+	existing, err := s.repo.FindByNameAndUser(name, userId)
+
+	if err == nil && existing.ID != exceptId {
+		return appError.BadRequest(
+			"Address book already exists",
+			nil,
+		)
+	}
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/utils"
+
+	"errors"
+	"strings"
+)
+
+// parseFieldset splits a comma separated sparse fieldset and checks each
+// name against the export field catalog. Duplicates are dropped; an empty
+// fieldset selects every field.
+func parseFieldset(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	var fields []string
+
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" || seen[f] {
+			continue
+		}
+
+		if _, ok := utils.AllowedAddressExportFields[f]; !ok {
+			return nil, appError.BadRequest(
+				"Invalid field: "+f,
+				errors.New(f),
+			)
+		}
+
+		seen[f] = true
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
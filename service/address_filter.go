@@ -0,0 +1,30 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/filter"
+	"address-book-server/utils"
+
+	"errors"
+)
+
+// applyFilter parses the filter expression of a list query against the
+// address filter whitelist and stores the result on the query.
+func applyFilter(query *dto.ListAddressQuery) error {
+	if query.Filter == "" {
+		return nil
+	}
+
+	node, err := filter.Parse(query.Filter, utils.AddressFilterFields)
+	if err != nil {
+		var filterErr *filter.Error
+		if errors.As(err, &filterErr) {
+			return appError.NewFilterError(filterErr.Message, filterErr.Pos, err)
+		}
+		return appError.BadRequest("Invalid filter", err)
+	}
+
+	query.FilterExpr = node
+	return nil
+}
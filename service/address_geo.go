@@ -0,0 +1,65 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+
+	"strconv"
+	"strings"
+)
+
+// applyGeo parses the near and bbox parameters of a list query.
+func applyGeo(query *dto.ListAddressQuery) error {
+	if query.Near != "" {
+		values, ok := parseCoordinates(query.Near, 2)
+		if !ok || !validLatitude(values[0]) || !validLongitude(values[1]) {
+			return appError.NewValidationError(map[string]string{
+				"near": "Must be lat,lng in degrees",
+			})
+		}
+		query.Point = &dto.GeoPoint{Lat: values[0], Lng: values[1]}
+	} else if query.RadiusKm != 0 {
+		return appError.NewValidationError(map[string]string{
+			"radius_km": "Requires near",
+		})
+	}
+
+	if query.BBox != "" {
+		values, ok := parseCoordinates(query.BBox, 4)
+		if !ok || !validLongitude(values[0]) || !validLatitude(values[1]) ||
+			!validLongitude(values[2]) || !validLatitude(values[3]) || values[1] > values[3] {
+			return appError.NewValidationError(map[string]string{
+				"bbox": "Must be west,south,east,north in degrees",
+			})
+		}
+		query.Box = &dto.GeoBox{West: values[0], South: values[1], East: values[2], North: values[3]}
+	}
+
+	return nil
+}
+
+func parseCoordinates(raw string, count int) ([]float64, bool) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != count {
+		return nil, false
+	}
+
+	values := make([]float64, 0, count)
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+
+	return values, true
+}
+
+func validLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+func validLongitude(lng float64) bool {
+	return lng >= -180 && lng <= 180
+}
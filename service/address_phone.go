@@ -0,0 +1,84 @@
+package service
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"address-book-server/phone"
+	"address-book-server/postal"
+
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// phoneRegion is the region national numbers of a contact are read in: its
+// country, or DEFAULT_PHONE_REGION when that names no known country.
+func phoneRegion(address *model.Address) string {
+	if country, ok := postal.LookupCountry(address.Country); ok {
+		return country.Alpha2
+	}
+	return strings.ToUpper(os.Getenv("DEFAULT_PHONE_REGION"))
+}
+
+// normalizePhones fills the E.164 and digit forms of every phone of a
+// contact. With report set, numbers that break their country's rules fail
+// validation, per entry as phones[i].phone when indexed and otherwise only
+// the primary one, as phone. Unreported failures, and plausible national
+// numbers without a region, are kept as typed with no E.164 form.
+func normalizePhones(address *model.Address, report, indexed bool) error {
+	region := phoneRegion(address)
+	primary := primaryIndex(len(address.Phones), func(i int) bool { return address.Phones[i].IsPrimary })
+	details := map[string]string{}
+
+	address.PhoneE164 = ""
+
+	for i := range address.Phones {
+		p := &address.Phones[i]
+
+		number, err := phone.Parse(p.Phone, region)
+		if err == nil {
+			p.E164 = number.E164
+			p.Digits = strings.TrimPrefix(number.E164, "+")
+		} else {
+			p.E164 = ""
+			p.Digits = phone.Digits(p.Phone)
+		}
+
+		if i == primary {
+			address.PhoneE164 = p.E164
+		}
+
+		if err == nil || (errors.Is(err, phone.ErrNoRegion) && phone.Plausible(p.Phone)) || !report {
+			continue
+		}
+
+		switch {
+		case indexed:
+			details["phones["+strconv.Itoa(i)+"].phone"] = phoneErrorMessage(number, err)
+		case i == primary:
+			details["phone"] = phoneErrorMessage(number, err)
+		}
+	}
+
+	if len(details) > 0 {
+		return appError.NewValidationError(details)
+	}
+
+	return nil
+}
+
+func phoneErrorMessage(number phone.Number, err error) string {
+	switch {
+	case errors.Is(err, phone.ErrCallingCode):
+		return "Unknown country calling code"
+	case errors.Is(err, phone.ErrNumberPlan) && number.Region != "":
+		if country, ok := postal.CountryByCode(number.Region); ok {
+			return "Not a valid phone number for " + country.Name
+		}
+		fallthrough
+	case errors.Is(err, phone.ErrNumberPlan):
+		return "Not a valid phone number for country code +" + number.CallingCode
+	}
+	return "Invalid phone number"
+}
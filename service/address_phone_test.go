@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	appError "address-book-server/error"
+	"address-book-server/model"
+)
+
+func TestNormalizePhones(t *testing.T) {
+	t.Setenv("DEFAULT_PHONE_REGION", "")
+
+	tests := []struct {
+		name    string
+		country string
+		phones  []string
+		indexed bool
+		details map[string]string
+		e164    string
+	}{
+		{"national number in its country", "India", []string{"098765 43210"}, false, nil, "+919876543210"},
+		{"national number without a region", "", []string{"98765 43210"}, false, nil, ""},
+		{"implausible number without a region", "", []string{"123456789012345678901234567890"}, false, map[string]string{"phone": "Invalid phone number"}, ""},
+		{"implausible entry without a region", "", []string{"+91 98765 43210", "1234"}, true, map[string]string{"phones[1].phone": "Invalid phone number"}, "+919876543210"},
+		{"number outside the country's plan", "US", []string{"555-0100"}, false, map[string]string{"phone": "Not a valid phone number for United States"}, ""},
+	}
+
+	for _, tt := range tests {
+		address := model.Address{Country: tt.country}
+		for _, p := range tt.phones {
+			address.Phones = append(address.Phones, model.AddressPhone{Phone: p})
+		}
+
+		err := normalizePhones(&address, true, tt.indexed)
+
+		var appErr *appError.AppError
+		switch {
+		case tt.details == nil && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.details != nil && !errors.As(err, &appErr):
+			t.Errorf("%s: error = %v, want validation error", tt.name, err)
+		case tt.details != nil:
+			if len(appErr.Details) != len(tt.details) {
+				t.Errorf("%s: details = %v, want %v", tt.name, appErr.Details, tt.details)
+			}
+			for key, want := range tt.details {
+				if got := appErr.Details[key]; got != want {
+					t.Errorf("%s: details[%q] = %q, want %q", tt.name, key, got, want)
+				}
+			}
+		}
+
+		if address.PhoneE164 != tt.e164 {
+			t.Errorf("%s: PhoneE164 = %q, want %q", tt.name, address.PhoneE164, tt.e164)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"address-book-server/postal"
+
+	"strconv"
+)
+
+// validatePostal checks postal addresses against the rules of their
+// country. With indexed set every entry of PostalAddresses is checked and
+// reported as postal_addresses[i].field; otherwise only the flat fields,
+// which mirror the primary entry, are checked under their own names.
+func validatePostal(address *model.Address, indexed bool) error {
+	details := map[string]string{}
+
+	if indexed {
+		for i, p := range address.PostalAddresses {
+			for field, message := range postal.Validate(postal.Address{
+				AddressLine1: p.AddressLine1,
+				AddressLine2: p.AddressLine2,
+				City:         p.City,
+				State:        p.State,
+				Country:      p.Country,
+				Pincode:      p.Pincode,
+			}) {
+				details["postal_addresses["+strconv.Itoa(i)+"]."+field] = message
+			}
+		}
+	} else {
+		details = postal.Validate(postal.Address{
+			AddressLine1: address.AddressLine1,
+			AddressLine2: address.AddressLine2,
+			City:         address.City,
+			State:        address.State,
+			Country:      address.Country,
+			Pincode:      address.Pincode,
+		})
+	}
+
+	if len(details) > 0 {
+		return appError.NewValidationError(details)
+	}
+
+	return nil
+}
+
+// autofillPostal fills missing cities and states from the postal code, on
+// the flat fields and every postal address, when POSTAL_AUTOFILL is on.
+// Codes outside the bundled sample are left alone.
+func autofillPostal(address *model.Address) {
+	if !postal.AutofillEnabled() {
+		return
+	}
+
+	fill := func(city, state *string, country, pincode string) {
+		a := postal.Address{City: *city, State: *state, Country: country, Pincode: pincode}
+		if postal.Autofill(&a) {
+			*city, *state = a.City, a.State
+		}
+	}
+
+	fill(&address.City, &address.State, address.Country, address.Pincode)
+	for i := range address.PostalAddresses {
+		p := &address.PostalAddresses[i]
+		fill(&p.City, &p.State, p.Country, p.Pincode)
+	}
+}
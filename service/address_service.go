@@ -3,44 +3,88 @@ package service
 import (
 	"address-book-server/dto"
 	appError "address-book-server/error"
+	"address-book-server/geocoding"
 	"address-book-server/logger"
 	"address-book-server/mapper"
 	"address-book-server/model"
+	"address-book-server/phone"
+	"address-book-server/postal"
 	"address-book-server/repository"
 	"address-book-server/utils"
 
 	"errors"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type AddressService interface {
-	Create(userId uint64, address *model.Address) error
+	Create(userId, bookId uint64, req *dto.CreateAddressRequest) error
 	List(userId uint64) ([]dto.ListAddressResponse, error)
-	Update(id, userId uint64, req *dto.UpdateAddressRequest) error
-	Delete(id, userId uint64) error
-	ExportCSV(userId uint64, fields []string) ([]byte, error)
-	ListWithFilters(userId uint64, query dto.ListAddressQuery) ([]dto.ListAddressResponse, int64, error)
+	// Get fills query.SelectFields with the parsed fieldset.
+	Get(id, userId, bookId uint64, query *dto.GetAddressQuery) (*dto.ListAddressResponse, error)
+	Update(id, userId, bookId uint64, req *dto.UpdateAddressRequest) error
+	Delete(id, userId, bookId uint64) error
+	ExportCSV(userId uint64, req dto.ExportAddressRequest) ([]byte, error)
+	// ListWithFilters normalizes query in place, e.g. Sort is rewritten to
+	// the order that was actually applied.
+	ListWithFilters(userId uint64, query *dto.ListAddressQuery) ([]dto.ListAddressResponse, *dto.ListAddressMeta, error)
+	Upcoming(userId, bookId uint64, days int) ([]dto.UpcomingEventResponse, error)
+	Move(userId, bookId uint64, req *dto.TransferAddressesRequest) error
+	Copy(userId, bookId uint64, req *dto.TransferAddressesRequest) ([]uint64, error)
+	Regeocode(id, userId, bookId uint64) error
 }
 
 type addressService struct {
-	repo repository.AddressRepository
+	repo            repository.AddressRepository
+	groupRepo       repository.GroupRepository
+	customFieldRepo repository.CustomFieldRepository
+	userRepo        repository.UserRepository
+	bookRepo        repository.AddressBookRepository
+	geocoding       GeocodingService
 }
 
-func NewAddressService(repo repository.AddressRepository) AddressService {
-	return &addressService{repo: repo}
+func NewAddressService(repo repository.AddressRepository, groupRepo repository.GroupRepository, customFieldRepo repository.CustomFieldRepository, userRepo repository.UserRepository, bookRepo repository.AddressBookRepository, geocoding GeocodingService) AddressService {
+	return &addressService{repo: repo, groupRepo: groupRepo, customFieldRepo: customFieldRepo, userRepo: userRepo, bookRepo: bookRepo, geocoding: geocoding}
 }
 
-func (s *addressService) Create(userId uint64, address *model.Address) error {
+func (s *addressService) Create(userId, bookId uint64, req *dto.CreateAddressRequest) error {
+	address := mapper.ToAddressModel(req)
 	address.UserID = userId
+	address.BookID = bookId
+	address.GeocodeStatus = model.GeocodePending
+
+	autofillPostal(&address)
+	syncContactDetails(&address)
+
+	if err := validatePostal(&address, len(req.PostalAddresses) > 0); err != nil {
+		return err
+	}
+
+	if err := normalizePhones(&address, true, len(req.Phones) > 0); err != nil {
+		return err
+	}
+
+	events, err := toContactEvents(req.Events)
+	if err != nil {
+		return err
+	}
+	address.Events = events
+
+	if err := s.applyCustomFields(userId, &address, req.CustomFields, true); err != nil {
+		return err
+	}
 
 	logger.Log.Info(
 		"Adding new Address",
 		zap.Uint64("user_id", userId),
 	)
 
-	if err := s.repo.Create(address); err != nil {
+	if err := s.repo.Create(&address); err != nil {
 
 		logger.Log.Error(
 			"Failed to create address",
@@ -59,6 +103,8 @@ func (s *addressService) Create(userId uint64, address *model.Address) error {
 		zap.String("email", address.Email),
 	)
 
+	s.geocoding.Enqueue(address.ID)
+
 	return nil
 }
 
@@ -93,14 +139,81 @@ func (s *addressService) List(userId uint64) ([]dto.ListAddressResponse, error)
 	return response, nil
 }
 
-func (s *addressService) ListWithFilters(userId uint64, query dto.ListAddressQuery) ([]dto.ListAddressResponse, int64, error) {
+func (s *addressService) ListWithFilters(userId uint64, query *dto.ListAddressQuery) ([]dto.ListAddressResponse, *dto.ListAddressMeta, error) {
 
 	logger.Log.Info(
 		"Finding Addresses",
 		zap.Uint64("user_id", userId),
 	)
 
-	addresses, total, err := s.repo.FindUserWithFilters(userId, query)
+	if query.GroupID != 0 {
+		if err := s.ensureGroup(query.GroupID, userId); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if query.Phonetic && query.SearchMode != "" && query.SearchMode != utils.SearchModeSubstring {
+		return nil, nil, appError.BadRequest(
+			"Phonetic search cannot be combined with search_mode "+query.SearchMode,
+			nil,
+		)
+	}
+
+	ranked := query.Search != "" &&
+		(query.SearchMode == utils.SearchModeFulltext || query.SearchMode == utils.SearchModeFuzzy)
+
+	query.SearchKey = utils.SearchKey(query.Search)
+	query.PhoneDigits = phone.SearchDigits(query.Search)
+	query.CityKey = utils.SearchKey(query.City)
+	query.CountryKey = utils.SearchKey(query.Country)
+	if country, ok := postal.LookupCountry(query.Country); ok {
+		query.CountryCode = country.Alpha2
+	}
+
+	if query.SearchMode == utils.SearchModeFuzzy && query.Threshold == 0 {
+		query.Threshold = utils.FuzzyThreshold()
+	}
+
+	if err := applyGeo(query); err != nil {
+		return nil, nil, err
+	}
+
+	sortFields, err := parseSort(query.Sort, ranked, query.Point != nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	query.SortFields = sortFields
+	query.Sort = formatSort(sortFields)
+
+	if err := applyCursor(query); err != nil {
+		return nil, nil, err
+	}
+
+	if err := applyFilter(query); err != nil {
+		return nil, nil, err
+	}
+
+	fields, err := parseFieldset(query.Fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	query.SelectFields = fields
+
+	eventRange, err := eventMonthDayRange(query.EventFrom, query.EventTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	query.EventRange = eventRange
+
+	if len(query.CustomFields) > 0 {
+		filters, err := s.normalizeCustomFieldFilters(userId, query.CustomFields)
+		if err != nil {
+			return nil, nil, err
+		}
+		query.CustomFields = filters
+	}
+
+	page, err := s.repo.FindUserWithFilters(userId, *query)
 
 	if err != nil {
 
@@ -109,34 +222,63 @@ func (s *addressService) ListWithFilters(userId uint64, query dto.ListAddressQue
 			zap.String("error", err.Error()),
 		)
 
-		return nil, 0, appError.Internal(
+		return nil, nil, appError.Internal(
 			"Failed to fetch addresses",
 			err,
 		)
 	}
 
-	resp := make([]dto.ListAddressResponse, 0, len(addresses))
-	for _, a := range addresses {
-		resp = append(resp, mapper.ToListAddressResponse(a))
+	owner, err := s.userRepo.FindByID(userId)
+	if err != nil {
+		return nil, nil, appError.Internal(
+			"Failed to fetch address book owner",
+			err,
+		)
+	}
+
+	resp := make([]dto.ListAddressResponse, 0, len(page.Addresses))
+	for _, a := range page.Addresses {
+		r := mapper.ToListAddressResponse(a)
+		r.Owner = &dto.OwnerResponse{Id: owner.ID, Email: owner.Email}
+		if ranked {
+			r.Search = &dto.SearchHitResponse{Mode: query.SearchMode, Score: a.SearchScore, Highlight: a.SearchHighlight}
+		}
+		if query.Point != nil {
+			distance := a.DistanceKm
+			r.DistanceKm = &distance
+		}
+		resp = append(resp, r)
 	}
 
 	logger.Log.Info(
 		"Addresses found",
-		zap.Int64("total", total),
+		zap.Int("count", len(page.Addresses)),
+		zap.Int64("total", page.Total),
 	)
 
-	return resp, total, nil
+	return resp, pageMeta(query, page), nil
 }
 
-func (s *addressService) Update(id, userId uint64, req *dto.UpdateAddressRequest) error {
+func (s *addressService) Get(id, userId, bookId uint64, query *dto.GetAddressQuery) (*dto.ListAddressResponse, error) {
 
 	logger.Log.Info(
-		"Updating Address",
-		zap.Uint64("address_id", id),
+		"Finding Address",
+		zap.Uint64("id", id),
 		zap.Uint64("user_id", userId),
 	)
 
-	address, err := s.repo.FindByIDAndUser(id, userId)
+	fields, err := parseFieldset(query.Fields)
+	if err != nil {
+		return nil, err
+	}
+	query.SelectFields = fields
+
+	address, err := s.repo.FindByIDWithFields(id, userId, fields)
+
+	if err == nil && address.BookID != bookId {
+		err = gorm.ErrRecordNotFound
+	}
+
 	if err != nil {
 
 		logger.Log.Error(
@@ -144,12 +286,110 @@ func (s *addressService) Update(id, userId uint64, req *dto.UpdateAddressRequest
 			zap.String("error", err.Error()),
 		)
 
-		return appError.NotFound(
+		return nil, appError.NotFound(
 			"Address not found",
 			err,
 		)
 	}
 
+	owner, err := s.userRepo.FindByID(userId)
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to fetch address book owner",
+			err,
+		)
+	}
+
+	response := mapper.ToListAddressResponse(*address)
+	response.Owner = &dto.OwnerResponse{Id: owner.ID, Email: owner.Email}
+
+	return &response, nil
+}
+
+func (s *addressService) Upcoming(userId, bookId uint64, days int) ([]dto.UpcomingEventResponse, error) {
+
+	logger.Log.Info(
+		"Finding upcoming events",
+		zap.Uint64("user_id", userId),
+		zap.Int("days", days),
+	)
+
+	addresses, err := s.repo.FindWithEvents(userId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch events",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch events",
+			err,
+		)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	until := today.AddDate(0, 0, days)
+
+	response := []dto.UpcomingEventResponse{}
+
+	for _, a := range addresses {
+		if a.BookID != bookId {
+			continue
+		}
+
+		for _, e := range a.Events {
+			next := utils.NextOccurrence(e.Month, e.Day, today)
+			if next.After(until) {
+				continue
+			}
+
+			var years *int
+			if e.Year != nil {
+				count := next.Year() - *e.Year
+				years = &count
+			}
+
+			response = append(response, dto.UpcomingEventResponse{
+				AddressId: a.ID,
+				FirstName: a.FirstName,
+				LastName:  a.LastName,
+				Type:      e.Type,
+				Label:     e.Label,
+				Date:      utils.FormatEventDate(e.Month, e.Day, e.Year),
+				NextDate:  next.Format(utils.DateLayout),
+				DaysUntil: daysBetween(today, next),
+				Years:     years,
+			})
+		}
+	}
+
+	sort.SliceStable(response, func(i, j int) bool {
+		if response[i].DaysUntil != response[j].DaysUntil {
+			return response[i].DaysUntil < response[j].DaysUntil
+		}
+		return response[i].AddressId < response[j].AddressId
+	})
+
+	return response, nil
+}
+
+func (s *addressService) Update(id, userId, bookId uint64, req *dto.UpdateAddressRequest) error {
+
+	logger.Log.Info(
+		"Updating Address",
+		zap.Uint64("address_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	address, err := s.findInBook(id, userId, bookId)
+	if err != nil {
+		return err
+	}
+
+	location := locationOf(address)
+
 	if req.FirstName != nil {
 		address.FirstName = *req.FirstName
 	}
@@ -181,6 +421,59 @@ func (s *addressService) Update(id, userId uint64, req *dto.UpdateAddressRequest
 		address.Pincode = *req.Pincode
 	}
 
+	if req.Emails != nil {
+		address.Emails = mapper.ToAddressEmails(*req.Emails)
+	} else if req.Email != nil {
+		upsertPrimaryEmail(address)
+	}
+
+	if req.Phones != nil {
+		address.Phones = mapper.ToAddressPhones(*req.Phones)
+	} else if req.Phone != nil {
+		upsertPrimaryPhone(address)
+	}
+
+	flatPostal := req.AddressLine1 != nil || req.AddressLine2 != nil || req.City != nil ||
+		req.State != nil || req.Country != nil || req.Pincode != nil
+
+	if req.PostalAddresses != nil {
+		address.PostalAddresses = mapper.ToPostalAddresses(*req.PostalAddresses)
+	} else if flatPostal {
+		upsertPrimaryPostalAddress(address)
+	}
+
+	if req.PostalAddresses != nil || flatPostal {
+		autofillPostal(address)
+	}
+
+	syncContactDetails(address)
+
+	// Only postal data the request touches is checked, so contacts saved
+	// before a rule existed can still be edited otherwise.
+	if req.PostalAddresses != nil || flatPostal {
+		if err := validatePostal(address, req.PostalAddresses != nil); err != nil {
+			return err
+		}
+	}
+
+	// Every number is reparsed, since a new country can change how national
+	// numbers read, but only those the request sets can fail.
+	if err := normalizePhones(address, req.Phones != nil || req.Phone != nil, req.Phones != nil); err != nil {
+		return err
+	}
+
+	if req.Events != nil {
+		events, err := toContactEvents(*req.Events)
+		if err != nil {
+			return err
+		}
+		address.Events = events
+	}
+
+	if err := s.applyCustomFields(userId, address, req.CustomFields, false); err != nil {
+		return err
+	}
+
 	if err := s.repo.Update(address); err != nil {
 
 		logger.Log.Error(
@@ -194,37 +487,91 @@ func (s *addressService) Update(id, userId uint64, req *dto.UpdateAddressRequest
 		)
 	}
 
+	// Update leaves the geocode columns alone, so a move is marked pending
+	// separately, the way Regeocode does.
+	relocated := locationOf(address) != location
+	if relocated {
+		address.GeocodeStatus = model.GeocodePending
+
+		if err := s.repo.UpdateGeocode(address); err != nil {
+
+			logger.Log.Error(
+				"Failed to queue geocoding",
+				zap.String("error", err.Error()),
+			)
+
+			return appError.Internal(
+				"Failed to queue geocoding",
+				err,
+			)
+		}
+	}
+
 	logger.Log.Info(
 		"Update Successfull",
 		zap.Uint64("address_id", id),
 		zap.Uint64("user_id", userId),
 	)
 
+	if relocated {
+		s.geocoding.Enqueue(address.ID)
+	}
+
 	return nil
 }
 
-func (s *addressService) Delete(id, userId uint64) error {
+// Regeocode queues a contact to be located again, e.g. after a failure or
+// when the provider has improved. The current coordinates stay until then.
+func (s *addressService) Regeocode(id, userId, bookId uint64) error {
+	address, err := s.findInBook(id, userId, bookId)
+	if err != nil {
+		return err
+	}
 
-	logger.Log.Info(
-		"Deleting Address",
-		zap.Uint64("address_id", id),
-		zap.Uint64("user_id", userId),
-	)
+	address.GeocodeStatus = model.GeocodePending
 
-	_, err := s.repo.FindByIDAndUser(id, userId)
-	if err != nil {
+	if err := s.repo.UpdateGeocode(address); err != nil {
 
 		logger.Log.Error(
-			"Address not found",
+			"Failed to queue geocoding",
 			zap.String("error", err.Error()),
 		)
 
-		return appError.NotFound(
-			"Address not found",
+		return appError.Internal(
+			"Failed to queue geocoding",
 			err,
 		)
 	}
 
+	s.geocoding.Enqueue(address.ID)
+
+	return nil
+}
+
+// locationOf is the part of a contact the geocoder looks at.
+func locationOf(address *model.Address) geocoding.Query {
+	return geocoding.Query{
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		City:         address.City,
+		State:        address.State,
+		Country:      address.Country,
+		Pincode:      address.Pincode,
+	}
+}
+
+func (s *addressService) Delete(id, userId, bookId uint64) error {
+
+	logger.Log.Info(
+		"Deleting Address",
+		zap.Uint64("address_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	if _, err := s.findInBook(id, userId, bookId); err != nil {
+		return err
+	}
+
 	if err := s.repo.SoftDelete(id, userId); err != nil {
 
 		logger.Log.Error(
@@ -247,15 +594,32 @@ func (s *addressService) Delete(id, userId uint64) error {
 	return nil
 }
 
-func (s *addressService) ExportCSV(userId uint64, fields []string) ([]byte, error) {
+func (s *addressService) ExportCSV(userId uint64, req dto.ExportAddressRequest) ([]byte, error) {
+	fields := req.Fields
 
 	logger.Log.Info(
 		"Exporting started in CSV format",
 		zap.Strings("fields", fields),
 	)
 
+	customFields, err := s.customFieldRepo.FindByUser(userId)
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to fetch custom fields",
+			err,
+		)
+	}
+
+	labels := make(map[string]string, len(utils.AllowedAddressExportFields)+len(customFields))
+	for key, label := range utils.AllowedAddressExportFields {
+		labels[key] = label
+	}
+	for _, cf := range customFields {
+		labels[utils.CustomFieldExportPrefix+cf.Key] = cf.Name
+	}
+
 	for _, f := range fields {
-		if _, ok := utils.AllowedAddressExportFields[f]; !ok {
+		if _, ok := labels[f]; !ok {
 			return nil, appError.BadRequest(
 				"Invalid export field: "+f,
 				errors.New(f),
@@ -263,7 +627,18 @@ func (s *addressService) ExportCSV(userId uint64, fields []string) ([]byte, erro
 		}
 	}
 
-	addresses, err := s.repo.FindByUser(userId)
+	var addresses []model.Address
+
+	if req.GroupID != nil {
+		if err := s.ensureGroup(*req.GroupID, userId); err != nil {
+			return nil, err
+		}
+
+		addresses, err = s.repo.FindByUserAndGroup(userId, *req.GroupID, req.IncludeSubgroups)
+	} else {
+		addresses, err = s.repo.FindByUser(userId)
+	}
+
 	if err != nil {
 		return nil, appError.NotFound(
 			"Address not found",
@@ -273,6 +648,15 @@ func (s *addressService) ExportCSV(userId uint64, fields []string) ([]byte, erro
 
 	records := make([]map[string]string, 0, len(addresses))
 	for _, a := range addresses {
+		if req.BookID != 0 && a.BookID != req.BookID {
+			continue
+		}
+
+		tags := make([]string, 0, len(a.Tags))
+		for _, t := range a.Tags {
+			tags = append(tags, t.Name)
+		}
+
 		records = append(records, map[string]string{
 			"id":            strconv.FormatUint(a.ID, 10),
 			"user_id":       strconv.FormatUint(a.UserID, 10),
@@ -280,14 +664,330 @@ func (s *addressService) ExportCSV(userId uint64, fields []string) ([]byte, erro
 			"last_name":     a.LastName,
 			"email":         a.Email,
 			"phone":         a.Phone,
+			"phone_e164":    a.PhoneE164,
 			"address_line1": a.AddressLine1,
 			"address_line2": a.AddressLine2,
 			"city":          a.City,
 			"state":         a.State,
 			"country":       a.Country,
 			"pincode":       a.Pincode,
+			"country_code":  a.CountryCode,
+			"state_code":    a.StateCode,
+			"postal_code":   a.PostalCode,
+			"tags":          strings.Join(tags, "; "),
+			"emails":           formatEmails(a.Emails),
+			"phones":           formatPhones(a.Phones),
+			"postal_addresses": formatPostalAddresses(a.PostalAddresses),
 		})
+
+		for _, v := range a.CustomFieldValues {
+			records[len(records)-1][utils.CustomFieldExportPrefix+v.Field.Key] = v.Value
+		}
+	}
+
+	return utils.GenerateAddressCSV(fields, labels, records)
+}
+
+func (s *addressService) Move(userId, bookId uint64, req *dto.TransferAddressesRequest) error {
+
+	logger.Log.Info(
+		"Moving addresses",
+		zap.Uint64("user_id", userId),
+		zap.Uint64("book_id", bookId),
+		zap.Uint64("target_book_id", req.TargetBookID),
+		zap.Int("addresses", len(req.AddressIDs)),
+	)
+
+	addresses, err := s.transferSource(userId, bookId, req)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint64, 0, len(addresses))
+	for _, a := range addresses {
+		ids = append(ids, a.ID)
+	}
+
+	if err := s.repo.MoveToBook(ids, userId, req.TargetBookID); err != nil {
+
+		logger.Log.Error(
+			"Failed to move addresses",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to move addresses",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// Copy duplicates contacts into another book, including their emails,
+// phones, postal addresses, events, custom fields, tags and groups. Photos,
+// notes and attachments stay with the original contact.
+func (s *addressService) Copy(userId, bookId uint64, req *dto.TransferAddressesRequest) ([]uint64, error) {
+
+	logger.Log.Info(
+		"Copying addresses",
+		zap.Uint64("user_id", userId),
+		zap.Uint64("book_id", bookId),
+		zap.Uint64("target_book_id", req.TargetBookID),
+		zap.Int("addresses", len(req.AddressIDs)),
+	)
+
+	addresses, err := s.transferSource(userId, bookId, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range addresses {
+		copyAddress(&addresses[i], req.TargetBookID)
+	}
+
+	if err := s.repo.CopyAll(addresses); err != nil {
+
+		logger.Log.Error(
+			"Failed to copy addresses",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to copy addresses",
+			err,
+		)
+	}
+
+	ids := make([]uint64, 0, len(addresses))
+	for _, a := range addresses {
+		ids = append(ids, a.ID)
+	}
+
+	return ids, nil
+}
+
+// transferSource checks the target book and loads the requested contacts,
+// all of which must live in the source book.
+func (s *addressService) transferSource(userId, bookId uint64, req *dto.TransferAddressesRequest) ([]model.Address, error) {
+	if req.TargetBookID == bookId {
+		return nil, appError.BadRequest(
+			"Target address book must differ from the source",
+			nil,
+		)
+	}
+
+	if _, err := s.bookRepo.FindByIDAndUser(req.TargetBookID, userId); err != nil {
+		return nil, appError.NotFound(
+			"Target address book not found",
+			err,
+		)
+	}
+
+	ids := uniqueIDs(req.AddressIDs)
+
+	addresses, err := s.repo.FindByIDsAndUser(ids, userId)
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to fetch addresses",
+			err,
+		)
+	}
+
+	inBook := 0
+	for _, a := range addresses {
+		if a.BookID == bookId {
+			inBook++
+		}
+	}
+
+	if inBook != len(ids) {
+		return nil, appError.NotFound(
+			"One or more addresses not found",
+			nil,
+		)
+	}
+
+	return addresses, nil
+}
+
+func (s *addressService) findInBook(id, userId, bookId uint64) (*model.Address, error) {
+	return findAddressInBook(s.repo, id, userId, bookId)
+}
+
+// findAddressInBook loads a contact of userId, reporting it as not found
+// unless it belongs to the book in scope.
+func findAddressInBook(repo repository.AddressRepository, id, userId, bookId uint64) (*model.Address, error) {
+	address, err := repo.FindByIDAndUser(id, userId)
+
+	if err == nil && address.BookID != bookId {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+
+		logger.Log.Error(
+			"Address not found",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.NotFound(
+			"Address not found",
+			err,
+		)
+	}
+
+	return address, nil
+}
+
+func copyAddress(address *model.Address, bookId uint64) {
+	address.ID = 0
+	address.BookID = bookId
+	address.PhotoKey = ""
+	address.ThumbnailKey = ""
+	address.PhotoContentType = ""
+	address.CreatedAt = time.Time{}
+	address.UpdatedAt = time.Time{}
+
+	for i := range address.Emails {
+		address.Emails[i].ID = 0
+		address.Emails[i].AddressID = 0
+	}
+	for i := range address.Phones {
+		address.Phones[i].ID = 0
+		address.Phones[i].AddressID = 0
+	}
+	for i := range address.PostalAddresses {
+		address.PostalAddresses[i].ID = 0
+		address.PostalAddresses[i].AddressID = 0
+	}
+	for i := range address.Events {
+		address.Events[i].ID = 0
+		address.Events[i].AddressID = 0
+	}
+	for i := range address.CustomFieldValues {
+		address.CustomFieldValues[i].ID = 0
+		address.CustomFieldValues[i].AddressID = 0
+	}
+}
+
+func (s *addressService) ensureGroup(groupId, userId uint64) error {
+	if _, err := s.groupRepo.FindByIDAndUser(groupId, userId); err != nil {
+
+		logger.Log.Error(
+			"Group not found",
+			zap.Uint64("group_id", groupId),
+			zap.String("error", err.Error()),
+		)
+
+		return appError.NotFound(
+			"Group not found",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// applyCustomFields validates the supplied custom field values against the
+// user's field definitions and merges them into the address. Required fields
+// must have a value once the merge is done: all of them on create, but on
+// update only those the request sets, so adding a required field later does
+// not block unrelated edits of existing contacts.
+func (s *addressService) applyCustomFields(userId uint64, address *model.Address, values map[string]interface{}, creating bool) error {
+	definitions, err := s.customFieldRepo.FindByUser(userId)
+	if err != nil {
+		return appError.Internal(
+			"Failed to fetch custom fields",
+			err,
+		)
+	}
+
+	byKey := make(map[string]model.CustomField, len(definitions))
+	for _, d := range definitions {
+		byKey[d.Key] = d
+	}
+
+	current := make(map[uint64]string, len(address.CustomFieldValues))
+	for _, v := range address.CustomFieldValues {
+		current[v.FieldID] = v.Value
+	}
+
+	details := map[string]string{}
+
+	for key, raw := range values {
+		field, ok := byKey[key]
+		if !ok {
+			details["custom_fields."+key] = "Unknown custom field"
+			continue
+		}
+
+		if raw == nil {
+			delete(current, field.ID)
+			continue
+		}
+
+		value, err := normalizeCustomFieldValue(field, raw)
+		if err != nil {
+			details["custom_fields."+key] = err.Error()
+			continue
+		}
+
+		current[field.ID] = value
+	}
+
+	for _, d := range definitions {
+		if _, supplied := values[d.Key]; !creating && !supplied {
+			continue
+		}
+		if value, ok := current[d.ID]; d.Required && (!ok || value == "") {
+			if _, reported := details["custom_fields."+d.Key]; !reported {
+				details["custom_fields."+d.Key] = "This field is required"
+			}
+		}
+	}
+
+	if len(details) > 0 {
+		return appError.NewValidationError(details)
+	}
+
+	address.CustomFieldValues = make([]model.CustomFieldValue, 0, len(current))
+	for _, d := range definitions {
+		if value, ok := current[d.ID]; ok {
+			address.CustomFieldValues = append(address.CustomFieldValues, model.CustomFieldValue{
+				AddressID: address.ID,
+				FieldID:   d.ID,
+				Value:     value,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *addressService) normalizeCustomFieldFilters(userId uint64, filters map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(filters))
+	details := map[string]string{}
+
+	for key, raw := range filters {
+		field, err := s.customFieldRepo.FindByKeyAndUser(key, userId)
+		if err != nil {
+			details["cf["+key+"]"] = "Unknown custom field"
+			continue
+		}
+
+		value, err := normalizeCustomFieldValue(*field, raw)
+		if err != nil {
+			details["cf["+key+"]"] = err.Error()
+			continue
+		}
+
+		normalized[key] = value
+	}
+
+	if len(details) > 0 {
+		return nil, appError.NewValidationError(details)
 	}
 
-	return utils.GenerateAddressCSV(fields, records)
+	return normalized, nil
 }
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"address-book-server/repository"
+	"address-book-server/utils"
+
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseSort turns "last_name,-city" into sort fields. An id tiebreaker is
+// appended, in the direction of the last field, so the order is total and
+// pages never overlap. Ranked searches may also sort by relevance, which is
+// their default, and searches near a point by distance, which then takes
+// precedence as the default.
+func parseSort(raw string, ranked, located bool) ([]dto.SortField, error) {
+	if strings.TrimSpace(raw) == "" {
+		raw = utils.DefaultAddressSort
+		if located {
+			raw = utils.DistanceSortField
+		} else if ranked {
+			raw = "-" + utils.RelevanceSortField
+		}
+	}
+
+	fields := []dto.SortField{}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+
+		field := dto.SortField{Field: strings.TrimLeft(part, "+-"), Desc: strings.HasPrefix(part, "-")}
+
+		_, ok := utils.AllowedAddressSortFields[field.Field]
+		ok = ok || (ranked && field.Field == utils.RelevanceSortField) || (located && field.Field == utils.DistanceSortField)
+
+		if !ok {
+			return nil, appError.NewValidationError(map[string]string{
+				"sort": "Unknown sort field: " + part,
+			})
+		}
+
+		if seen[field.Field] {
+			return nil, appError.NewValidationError(map[string]string{
+				"sort": "Duplicate sort field: " + field.Field,
+			})
+		}
+
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+
+	if !seen["id"] {
+		fields = append(fields, dto.SortField{Field: "id", Desc: fields[len(fields)-1].Desc})
+	}
+
+	return fields, nil
+}
+
+func formatSort(fields []dto.SortField) string {
+	parts := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		if f.Desc {
+			parts = append(parts, "-"+f.Field)
+		} else {
+			parts = append(parts, f.Field)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// applyCursor validates the after/before parameters and decodes the cursor
+// into typed keyset values for the repository.
+func applyCursor(query *dto.ListAddressQuery) error {
+	if query.After != "" && query.Before != "" {
+		return appError.NewValidationError(map[string]string{
+			"before": "Cannot be combined with after",
+		})
+	}
+
+	token := query.After
+	if query.Before != "" {
+		token = query.Before
+	}
+
+	if token == "" {
+		return nil
+	}
+
+	if query.Page > 1 {
+		return appError.NewValidationError(map[string]string{
+			"page": "Cannot be combined with after or before",
+		})
+	}
+
+	cursor, err := utils.DecodeCursor(token)
+	if err != nil || len(cursor.Values) != len(query.SortFields) {
+		return appError.BadRequest(
+			"Invalid cursor",
+			err,
+		)
+	}
+
+	if cursor.Sort != query.Sort {
+		return appError.BadRequest(
+			"Cursor was issued for sort "+cursor.Sort+", not "+query.Sort,
+			nil,
+		)
+	}
+
+	values := make([]interface{}, 0, len(cursor.Values))
+
+	for i, f := range query.SortFields {
+		value, err := parseSortValue(f.Field, cursor.Values[i])
+		if err != nil {
+			return appError.BadRequest(
+				"Invalid cursor",
+				err,
+			)
+		}
+		values = append(values, value)
+	}
+
+	query.Keyset = values
+	query.KeysetBefore = query.Before != ""
+
+	if query.Count == nil {
+		count := false
+		query.Count = &count
+	}
+
+	return nil
+}
+
+func parseSortValue(field, raw string) (interface{}, error) {
+	switch field {
+	case "id":
+		return strconv.ParseUint(raw, 10, 64)
+	case "created_at", "updated_at":
+		return time.Parse(time.RFC3339Nano, raw)
+	case utils.RelevanceSortField, utils.DistanceSortField:
+		return strconv.ParseFloat(raw, 64)
+	}
+	return raw, nil
+}
+
+func sortValue(a model.Address, field string) string {
+	switch field {
+	case "id":
+		return strconv.FormatUint(a.ID, 10)
+	case "first_name":
+		return a.FirstName
+	case "last_name":
+		return a.LastName
+	case "email":
+		return a.Email
+	case "phone":
+		return a.Phone
+	case "city":
+		return a.City
+	case "state":
+		return a.State
+	case "country":
+		return a.Country
+	case "pincode":
+		return a.Pincode
+	case "created_at":
+		return a.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return a.UpdatedAt.Format(time.RFC3339Nano)
+	case utils.RelevanceSortField:
+		return strconv.FormatFloat(a.SearchScore, 'g', -1, 64)
+	case utils.DistanceSortField:
+		return strconv.FormatFloat(a.DistanceKm, 'g', -1, 64)
+	}
+	return ""
+}
+
+func cursorFor(a model.Address, query *dto.ListAddressQuery) string {
+	values := make([]string, 0, len(query.SortFields))
+
+	for _, f := range query.SortFields {
+		values = append(values, sortValue(a, f.Field))
+	}
+
+	return utils.EncodeCursor(utils.Cursor{Sort: query.Sort, Values: values})
+}
+
+// pageMeta works out the cursors around a page. A page reached through a
+// cursor always has rows on the side it came from.
+func pageMeta(query *dto.ListAddressQuery, page *repository.AddressPage) *dto.ListAddressMeta {
+	meta := &dto.ListAddressMeta{}
+
+	if page.Total >= 0 {
+		total := page.Total
+		meta.Total = &total
+	}
+
+	if len(page.Addresses) == 0 {
+		return meta
+	}
+
+	first := page.Addresses[0]
+	last := page.Addresses[len(page.Addresses)-1]
+
+	switch {
+	case query.KeysetBefore:
+		meta.NextCursor = cursorFor(last, query)
+		if page.HasMore {
+			meta.PrevCursor = cursorFor(first, query)
+		}
+	default:
+		if page.HasMore {
+			meta.NextCursor = cursorFor(last, query)
+		}
+		if query.After != "" || query.Page > 1 {
+			meta.PrevCursor = cursorFor(first, query)
+		}
+	}
+
+	return meta
+}
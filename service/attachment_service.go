@@ -0,0 +1,256 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/mapper"
+	"address-book-server/model"
+	"address-book-server/repository"
+	"address-book-server/storage"
+
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.uber.org/zap"
+)
+
+type AttachmentService interface {
+	Upload(addressId, userId, bookId uint64, fileName string, data []byte) (*dto.AttachmentResponse, error)
+	List(addressId, userId, bookId uint64) ([]dto.AttachmentResponse, error)
+	Download(id, addressId, userId, bookId uint64) (*model.Attachment, []byte, error)
+	Rename(id, addressId, userId, bookId uint64, req *dto.UpdateAttachmentRequest) (*dto.AttachmentResponse, error)
+	Delete(id, addressId, userId, bookId uint64) error
+}
+
+type attachmentService struct {
+	repo        repository.AttachmentRepository
+	addressRepo repository.AddressRepository
+	store       storage.BlobStore
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository, addressRepo repository.AddressRepository, store storage.BlobStore) AttachmentService {
+	return &attachmentService{repo: repo, addressRepo: addressRepo, store: store}
+}
+
+func (s *attachmentService) Upload(addressId, userId, bookId uint64, fileName string, data []byte) (*dto.AttachmentResponse, error) {
+
+	logger.Log.Info(
+		"Uploading attachment",
+		zap.Uint64("address_id", addressId),
+		zap.Uint64("user_id", userId),
+		zap.Int("size", len(data)),
+	)
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, err
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, appError.Internal(
+			"Failed to store attachment",
+			err,
+		)
+	}
+
+	attachment := model.Attachment{
+		AddressID:   addressId,
+		UserID:      userId,
+		FileName:    cleanFileName(fileName),
+		ContentType: mimetype.Detect(data).String(),
+		Size:        int64(len(data)),
+		BlobKey:     fmt.Sprintf("attachments/%d/%d/%s", userId, addressId, hex.EncodeToString(suffix)),
+	}
+
+	if err := s.store.Put(attachment.BlobKey, data); err != nil {
+
+		logger.Log.Error(
+			"Failed to store attachment",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to store attachment",
+			err,
+		)
+	}
+
+	if err := s.repo.Create(&attachment); err != nil {
+
+		logger.Log.Error(
+			"Failed to save attachment",
+			zap.String("error", err.Error()),
+		)
+
+		s.store.Delete(attachment.BlobKey)
+
+		return nil, appError.Internal(
+			"Failed to save attachment",
+			err,
+		)
+	}
+
+	response := mapper.ToAttachmentResponse(attachment, bookId)
+
+	return &response, nil
+}
+
+func (s *attachmentService) List(addressId, userId, bookId uint64) ([]dto.AttachmentResponse, error) {
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.FindByAddress(addressId, userId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch attachments",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch attachments",
+			err,
+		)
+	}
+
+	response := make([]dto.AttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		response = append(response, mapper.ToAttachmentResponse(a, bookId))
+	}
+
+	return response, nil
+}
+
+func (s *attachmentService) Download(id, addressId, userId, bookId uint64) (*model.Attachment, []byte, error) {
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.repo.FindByIDAndAddress(id, addressId, userId)
+	if err != nil {
+		return nil, nil, appError.NotFound(
+			"Attachment not found",
+			err,
+		)
+	}
+
+	data, err := s.store.Get(attachment.BlobKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, nil, appError.NotFound(
+			"Attachment not found",
+			err,
+		)
+	}
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to read attachment",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, nil, appError.Internal(
+			"Failed to read attachment",
+			err,
+		)
+	}
+
+	return attachment, data, nil
+}
+
+func (s *attachmentService) Rename(id, addressId, userId, bookId uint64, req *dto.UpdateAttachmentRequest) (*dto.AttachmentResponse, error) {
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.repo.FindByIDAndAddress(id, addressId, userId)
+	if err != nil {
+		return nil, appError.NotFound(
+			"Attachment not found",
+			err,
+		)
+	}
+
+	attachment.FileName = cleanFileName(req.FileName)
+
+	if err := s.repo.Update(attachment); err != nil {
+
+		logger.Log.Error(
+			"Failed to update attachment",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to update attachment",
+			err,
+		)
+	}
+
+	response := mapper.ToAttachmentResponse(*attachment, bookId)
+
+	return &response, nil
+}
+
+func (s *attachmentService) Delete(id, addressId, userId, bookId uint64) error {
+
+	logger.Log.Info(
+		"Deleting attachment",
+		zap.Uint64("attachment_id", id),
+		zap.Uint64("address_id", addressId),
+		zap.Uint64("user_id", userId),
+	)
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindByIDAndAddress(id, addressId, userId); err != nil {
+		return appError.NotFound(
+			"Attachment not found",
+			err,
+		)
+	}
+
+	if err := s.repo.SoftDelete(id, addressId, userId); err != nil {
+
+		logger.Log.Error(
+			"Failed to delete attachment",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to delete attachment",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// cleanFileName strips any client supplied directory components and control
+// characters so the name is safe to echo back in a Content-Disposition header.
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+
+	return name
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/repository"
+	"address-book-server/utils"
+
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type CalendarService interface {
+	RegenerateToken(userId uint64) (string, error)
+	RevokeToken(userId uint64) error
+	Feed(token string) ([]byte, error)
+}
+
+type calendarService struct {
+	userRepo    repository.UserRepository
+	addressRepo repository.AddressRepository
+}
+
+func NewCalendarService(userRepo repository.UserRepository, addressRepo repository.AddressRepository) CalendarService {
+	return &calendarService{userRepo: userRepo, addressRepo: addressRepo}
+}
+
+// RegenerateToken issues a new feed token. Only its hash is stored, so the
+// previous feed URL stops working immediately.
+func (s *calendarService) RegenerateToken(userId uint64) (string, error) {
+
+	logger.Log.Info(
+		"Regenerating calendar feed token",
+		zap.Uint64("user_id", userId),
+	)
+
+	token, err := utils.NewSecretToken()
+	if err != nil {
+		return "", appError.Internal(
+			"Failed to generate calendar token",
+			err,
+		)
+	}
+
+	hash := utils.HashSecretToken(token)
+
+	if err := s.userRepo.UpdateCalendarTokenHash(userId, &hash); err != nil {
+
+		logger.Log.Error(
+			"Failed to save calendar token",
+			zap.String("error", err.Error()),
+		)
+
+		return "", appError.Internal(
+			"Failed to generate calendar token",
+			err,
+		)
+	}
+
+	return token, nil
+}
+
+func (s *calendarService) RevokeToken(userId uint64) error {
+
+	logger.Log.Info(
+		"Revoking calendar feed token",
+		zap.Uint64("user_id", userId),
+	)
+
+	if err := s.userRepo.UpdateCalendarTokenHash(userId, nil); err != nil {
+		return appError.Internal(
+			"Failed to revoke calendar token",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *calendarService) Feed(token string) ([]byte, error) {
+
+	user, err := s.userRepo.FindByCalendarTokenHash(utils.HashSecretToken(token))
+	if err != nil {
+		return nil, appError.NotFound(
+			"Calendar not found",
+			err,
+		)
+	}
+
+	addresses, err := s.addressRepo.FindWithEvents(user.ID)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch events",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch events",
+			err,
+		)
+	}
+
+	events := []utils.ICalEvent{}
+
+	for _, a := range addresses {
+		name := strings.TrimSpace(a.FirstName + " " + a.LastName)
+
+		for _, e := range a.Events {
+			// Yearless events need an anchor year; a leap year keeps Feb 29 valid.
+			year := 2000
+			if e.Year != nil {
+				year = *e.Year
+			}
+
+			events = append(events, utils.ICalEvent{
+				UID:               fmt.Sprintf("contact-event-%d@address-book-server", e.ID),
+				Summary:           eventSummary(e.Type, e.Label, name),
+				Start:             time.Date(year, time.Month(e.Month), e.Day, 0, 0, 0, 0, time.UTC),
+				LastDayOfFebruary: e.Month == 2 && e.Day == 29,
+			})
+		}
+	}
+
+	return utils.GenerateICalendar("Address Book", events, time.Now()), nil
+}
+
+func eventSummary(eventType, label, name string) string {
+	switch {
+	case label != "":
+		return label + ": " + name
+	case eventType == "birthday":
+		return name + "'s Birthday"
+	case eventType == "anniversary":
+		return name + "'s Anniversary"
+	}
+	return name
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"address-book-server/model"
+	"address-book-server/postal"
+	"address-book-server/utils"
+
+	"strings"
+)
+
+const defaultDetailType = "other"
+
+// syncContactDetails keeps the flat contact columns and the child collections
+// consistent. Collections that were not supplied are seeded from the flat
+// fields, each collection ends up with exactly one primary entry, and the
+// primary values are copied back onto the flat columns. The normalized search
+// keys and postal codes are refreshed last, from the final values.
+func syncContactDetails(address *model.Address) {
+	if len(address.Emails) == 0 && address.Email != "" {
+		address.Emails = []model.AddressEmail{{Email: address.Email, IsPrimary: true}}
+	}
+	if len(address.Phones) == 0 && address.Phone != "" {
+		address.Phones = []model.AddressPhone{{Phone: address.Phone, IsPrimary: true}}
+	}
+	if len(address.PostalAddresses) == 0 && address.AddressLine1 != "" {
+		address.PostalAddresses = []model.PostalAddress{flatPostalAddress(address)}
+	}
+
+	address.Email = ""
+	if i := primaryIndex(len(address.Emails), func(i int) bool { return address.Emails[i].IsPrimary }); i >= 0 {
+		for j := range address.Emails {
+			address.Emails[j].IsPrimary = j == i
+			if address.Emails[j].Type == "" {
+				address.Emails[j].Type = defaultDetailType
+			}
+		}
+		address.Email = address.Emails[i].Email
+	}
+
+	address.Phone = ""
+	if i := primaryIndex(len(address.Phones), func(i int) bool { return address.Phones[i].IsPrimary }); i >= 0 {
+		for j := range address.Phones {
+			address.Phones[j].IsPrimary = j == i
+			if address.Phones[j].Type == "" {
+				address.Phones[j].Type = defaultDetailType
+			}
+		}
+		address.Phone = address.Phones[i].Phone
+	}
+
+	primary := model.PostalAddress{}
+	if i := primaryIndex(len(address.PostalAddresses), func(i int) bool { return address.PostalAddresses[i].IsPrimary }); i >= 0 {
+		for j := range address.PostalAddresses {
+			address.PostalAddresses[j].IsPrimary = j == i
+			if address.PostalAddresses[j].Type == "" {
+				address.PostalAddresses[j].Type = defaultDetailType
+			}
+		}
+		primary = address.PostalAddresses[i]
+	}
+
+	address.AddressLine1 = primary.AddressLine1
+	address.AddressLine2 = primary.AddressLine2
+	address.City = primary.City
+	address.State = primary.State
+	address.Country = primary.Country
+	address.Pincode = primary.Pincode
+
+	utils.ApplySearchKeys(address)
+	postal.ApplyCodes(address)
+}
+
+// upsertPrimaryEmail applies a flat email update to the primary entry.
+func upsertPrimaryEmail(address *model.Address) {
+	i := primaryIndex(len(address.Emails), func(i int) bool { return address.Emails[i].IsPrimary })
+
+	switch {
+	case address.Email == "" && i >= 0:
+		address.Emails = append(address.Emails[:i], address.Emails[i+1:]...)
+	case i >= 0:
+		address.Emails[i].Email = address.Email
+	case address.Email != "":
+		address.Emails = append(address.Emails, model.AddressEmail{Email: address.Email, IsPrimary: true})
+	}
+}
+
+// upsertPrimaryPhone applies a flat phone update to the primary entry.
+func upsertPrimaryPhone(address *model.Address) {
+	i := primaryIndex(len(address.Phones), func(i int) bool { return address.Phones[i].IsPrimary })
+
+	switch {
+	case address.Phone == "" && i >= 0:
+		address.Phones = append(address.Phones[:i], address.Phones[i+1:]...)
+	case i >= 0:
+		address.Phones[i].Phone = address.Phone
+	case address.Phone != "":
+		address.Phones = append(address.Phones, model.AddressPhone{Phone: address.Phone, IsPrimary: true})
+	}
+}
+
+// upsertPrimaryPostalAddress applies flat postal field updates to the
+// primary entry.
+func upsertPrimaryPostalAddress(address *model.Address) {
+	i := primaryIndex(len(address.PostalAddresses), func(i int) bool { return address.PostalAddresses[i].IsPrimary })
+
+	if i < 0 {
+		if address.AddressLine1 != "" {
+			address.PostalAddresses = append(address.PostalAddresses, flatPostalAddress(address))
+		}
+		return
+	}
+
+	postal := &address.PostalAddresses[i]
+	postal.AddressLine1 = address.AddressLine1
+	postal.AddressLine2 = address.AddressLine2
+	postal.City = address.City
+	postal.State = address.State
+	postal.Country = address.Country
+	postal.Pincode = address.Pincode
+}
+
+func flatPostalAddress(address *model.Address) model.PostalAddress {
+	return model.PostalAddress{
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		City:         address.City,
+		State:        address.State,
+		Country:      address.Country,
+		Pincode:      address.Pincode,
+		IsPrimary:    true,
+	}
+}
+
+// primaryIndex returns the first entry flagged as primary, falling back to
+// the first entry, or -1 for an empty collection.
+func primaryIndex(n int, isPrimary func(int) bool) int {
+	if n == 0 {
+		return -1
+	}
+
+	for i := 0; i < n; i++ {
+		if isPrimary(i) {
+			return i
+		}
+	}
+
+	return 0
+}
+
+func formatEmails(emails []model.AddressEmail) string {
+	values := make([]string, 0, len(emails))
+	for _, e := range emails {
+		values = append(values, detailLabel(e.Type, e.Label)+e.Email)
+	}
+	return strings.Join(values, "; ")
+}
+
+func formatPhones(phones []model.AddressPhone) string {
+	values := make([]string, 0, len(phones))
+	for _, p := range phones {
+		values = append(values, detailLabel(p.Type, p.Label)+p.Phone)
+	}
+	return strings.Join(values, "; ")
+}
+
+func formatPostalAddresses(postals []model.PostalAddress) string {
+	values := make([]string, 0, len(postals))
+	for _, p := range postals {
+		parts := []string{}
+		for _, part := range []string{p.AddressLine1, p.AddressLine2, p.City, p.State, p.Pincode, p.Country} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		values = append(values, detailLabel(p.Type, p.Label)+strings.Join(parts, ", "))
+	}
+	return strings.Join(values, "; ")
+}
+
+func detailLabel(detailType, label string) string {
+	if label != "" {
+		return label + ": "
+	}
+	return detailType + ": "
+}
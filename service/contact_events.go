@@ -0,0 +1,92 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"address-book-server/utils"
+
+	"strconv"
+	"time"
+)
+
+func toContactEvents(requests []dto.EventRequest) ([]model.ContactEvent, error) {
+	events := make([]model.ContactEvent, 0, len(requests))
+	details := map[string]string{}
+
+	for i, r := range requests {
+		month, day, year, err := utils.ParseEventDate(r.Date)
+		if err != nil {
+			details["events["+strconv.Itoa(i)+"].date"] = "Must be a date in YYYY-MM-DD or --MM-DD format"
+			continue
+		}
+
+		events = append(events, model.ContactEvent{
+			Type:  r.Type,
+			Label: r.Label,
+			Month: month,
+			Day:   day,
+			Year:  year,
+		})
+	}
+
+	if len(details) > 0 {
+		return nil, appError.NewValidationError(details)
+	}
+
+	return events, nil
+}
+
+// eventMonthDayRange turns the event_from/event_to filter into a range of
+// MMDD values. Ranges spanning a year or more match every date.
+func eventMonthDayRange(from, to string) (*dto.MonthDayRange, error) {
+	if from == "" && to == "" {
+		return nil, nil
+	}
+
+	details := map[string]string{}
+
+	start, err := time.Parse(utils.DateLayout, from)
+	if err != nil {
+		details["event_from"] = "Must be a date in YYYY-MM-DD format"
+	}
+
+	end, err := time.Parse(utils.DateLayout, to)
+	if err != nil {
+		details["event_to"] = "Must be a date in YYYY-MM-DD format"
+	}
+
+	if len(details) > 0 {
+		return nil, appError.NewValidationError(details)
+	}
+
+	if end.Before(start) {
+		return nil, appError.NewValidationError(map[string]string{
+			"event_to": "Must not be before event_from",
+		})
+	}
+
+	if !end.Before(start.AddDate(1, 0, 0)) {
+		return &dto.MonthDayRange{All: true}, nil
+	}
+
+	toMonthDay := int(end.Month())*100 + end.Day()
+
+	// Feb 29 events are observed on Feb 28 in non-leap years.
+	if toMonthDay == 228 && !utils.IsLeapYear(end.Year()) {
+		toMonthDay = 229
+	}
+
+	return &dto.MonthDayRange{
+		From: int(start.Month())*100 + start.Day(),
+		To:   toMonthDay,
+	}, nil
+}
+
+// daysBetween counts calendar days, which stays correct across DST changes
+// where dividing durations by 24h would not.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
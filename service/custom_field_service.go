@@ -0,0 +1,268 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/mapper"
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type CustomFieldService interface {
+	Create(userId uint64, req *dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error)
+	List(userId uint64) ([]dto.CustomFieldResponse, error)
+	Update(id, userId uint64, req *dto.UpdateCustomFieldRequest) error
+	Delete(id, userId uint64) error
+}
+
+type customFieldService struct {
+	repo repository.CustomFieldRepository
+}
+
+func NewCustomFieldService(repo repository.CustomFieldRepository) CustomFieldService {
+	return &customFieldService{repo: repo}
+}
+
+func (s *customFieldService) Create(userId uint64, req *dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+
+	logger.Log.Info(
+		"Creating custom field",
+		zap.Uint64("user_id", userId),
+		zap.String("key", req.Key),
+	)
+
+	if !customFieldKeyPattern.MatchString(req.Key) {
+		return nil, appError.NewValidationError(map[string]string{
+			"key": "Must start with a lowercase letter and contain only lowercase letters, digits and underscores",
+		})
+	}
+
+	_, err := s.repo.FindByKeyAndUser(req.Key, userId)
+
+	if err == nil {
+		return nil, appError.BadRequest(
+			"Custom field already exists",
+			nil,
+		)
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	field := model.CustomField{
+		UserID:   userId,
+		Key:      req.Key,
+		Name:     strings.TrimSpace(req.Name),
+		Type:     req.Type,
+		Required: req.Required,
+	}
+
+	if req.Type == model.CustomFieldEnum {
+		field.Options = req.Options
+	}
+
+	if err := s.repo.Create(&field); err != nil {
+
+		logger.Log.Error(
+			"Failed to create custom field",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create custom field",
+			err,
+		)
+	}
+
+	response := mapper.ToCustomFieldResponse(field)
+
+	return &response, nil
+}
+
+func (s *customFieldService) List(userId uint64) ([]dto.CustomFieldResponse, error) {
+
+	fields, err := s.repo.FindByUser(userId)
+
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch custom fields",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch custom fields",
+			err,
+		)
+	}
+
+	response := make([]dto.CustomFieldResponse, 0, len(fields))
+	for _, f := range fields {
+		response = append(response, mapper.ToCustomFieldResponse(f))
+	}
+
+	return response, nil
+}
+
+func (s *customFieldService) Update(id, userId uint64, req *dto.UpdateCustomFieldRequest) error {
+
+	logger.Log.Info(
+		"Updating custom field",
+		zap.Uint64("field_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	field, err := s.repo.FindByIDAndUser(id, userId)
+	if err != nil {
+		return appError.NotFound(
+			"Custom field not found",
+			err,
+		)
+	}
+
+	if req.Name != nil {
+		field.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Required != nil {
+		field.Required = *req.Required
+	}
+	if req.Options != nil {
+		if field.Type != model.CustomFieldEnum {
+			return appError.NewValidationError(map[string]string{
+				"options": "Options are only supported for enum fields",
+			})
+		}
+		if len(*req.Options) == 0 {
+			return appError.NewValidationError(map[string]string{
+				"options": "This field is required",
+			})
+		}
+		field.Options = *req.Options
+	}
+
+	if err := s.repo.Update(field); err != nil {
+
+		logger.Log.Error(
+			"Failed to update custom field",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to update custom field",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *customFieldService) Delete(id, userId uint64) error {
+
+	logger.Log.Info(
+		"Deleting custom field",
+		zap.Uint64("field_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	if _, err := s.repo.FindByIDAndUser(id, userId); err != nil {
+		return appError.NotFound(
+			"Custom field not found",
+			err,
+		)
+	}
+
+	if err := s.repo.Delete(id, userId); err != nil {
+
+		logger.Log.Error(
+			"Failed to delete custom field",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to delete custom field",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// normalizeCustomFieldValue checks a JSON-decoded value against the field type
+// and returns its canonical string form for storage and comparison.
+func normalizeCustomFieldValue(field model.CustomField, raw interface{}) (string, error) {
+	switch field.Type {
+
+	case model.CustomFieldString:
+		value, ok := raw.(string)
+		if !ok {
+			return "", errors.New("Must be a string")
+		}
+		return value, nil
+
+	case model.CustomFieldNumber:
+		switch value := raw.(type) {
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), nil
+		case string:
+			number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return "", errors.New("Must be a number")
+			}
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+		return "", errors.New("Must be a number")
+
+	case model.CustomFieldDate:
+		value, ok := raw.(string)
+		if !ok {
+			return "", errors.New("Must be a date in YYYY-MM-DD format")
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return "", errors.New("Must be a date in YYYY-MM-DD format")
+		}
+		return value, nil
+
+	case model.CustomFieldBoolean:
+		switch value := raw.(type) {
+		case bool:
+			return strconv.FormatBool(value), nil
+		case string:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return "", errors.New("Must be true or false")
+			}
+			return strconv.FormatBool(parsed), nil
+		}
+		return "", errors.New("Must be true or false")
+
+	case model.CustomFieldEnum:
+		value, ok := raw.(string)
+		if ok {
+			for _, option := range field.Options {
+				if option == value {
+					return value, nil
+				}
+			}
+		}
+		return "", fmt.Errorf("Must be one of: %s", strings.Join(field.Options, ", "))
+	}
+
+	return "", errors.New("Unsupported field type")
+}
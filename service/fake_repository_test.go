@@ -0,0 +1,82 @@
+package service
+
+import (
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// fakeAddressRepository keeps contacts in memory for the methods the
+// geocoding and photo services use. Any other method panics through the
+// nil embedded interface.
+type fakeAddressRepository struct {
+	repository.AddressRepository
+
+	mu        sync.Mutex
+	addresses map[uint64]model.Address
+	// geocoded receives every contact stored through UpdateGeocode.
+	geocoded chan model.Address
+}
+
+func newFakeAddressRepository(addresses ...model.Address) *fakeAddressRepository {
+	r := &fakeAddressRepository{
+		addresses: map[uint64]model.Address{},
+		geocoded:  make(chan model.Address, 10),
+	}
+	for _, address := range addresses {
+		r.addresses[address.ID] = address
+	}
+	return r
+}
+
+func (r *fakeAddressRepository) FindByID(id uint64) (*model.Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	address, ok := r.addresses[id]
+	if !ok || address.IsDeleted {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &address, nil
+}
+
+func (r *fakeAddressRepository) FindByIDAndUser(id, userID uint64) (*model.Address, error) {
+	address, err := r.FindByID(id)
+	if err != nil || address.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return address, nil
+}
+
+func (r *fakeAddressRepository) UpdateGeocode(address *model.Address) error {
+	r.mu.Lock()
+	stored := r.addresses[address.ID]
+	stored.Latitude = address.Latitude
+	stored.Longitude = address.Longitude
+	stored.GeocodeStatus = address.GeocodeStatus
+	stored.GeocodeAccuracy = address.GeocodeAccuracy
+	stored.GeocodedAt = address.GeocodedAt
+	r.addresses[address.ID] = stored
+	r.mu.Unlock()
+
+	r.geocoded <- stored
+	return nil
+}
+
+func (r *fakeAddressRepository) UpdatePhoto(id, userID uint64, photoKey, thumbnailKey, contentType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.addresses[id]
+	if !ok || stored.UserID != userID {
+		return nil
+	}
+	stored.PhotoKey = photoKey
+	stored.ThumbnailKey = thumbnailKey
+	stored.PhotoContentType = contentType
+	r.addresses[id] = stored
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"address-book-server/geocoding"
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	geocodeQueueSize = 1000
+	geocodeTimeout   = 30 * time.Second
+)
+
+// GeocodingService locates contacts in the background. Contacts waiting for
+// a worker have the pending status, so none are lost if the queue is full or
+// the server stops: ResumePending picks them up again.
+type GeocodingService interface {
+	Enqueue(addressId uint64)
+	ResumePending()
+}
+
+type geocodingService struct {
+	repo     repository.AddressRepository
+	geocoder geocoding.Geocoder
+	queue    chan uint64
+}
+
+// NewGeocodingService starts workers goroutines that geocode queued
+// contacts.
+func NewGeocodingService(repo repository.AddressRepository, geocoder geocoding.Geocoder, workers int) GeocodingService {
+	s := &geocodingService{repo: repo, geocoder: geocoder, queue: make(chan uint64, geocodeQueueSize)}
+
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+
+	return s
+}
+
+func (s *geocodingService) Enqueue(addressId uint64) {
+	select {
+	case s.queue <- addressId:
+	default:
+		logger.Log.Warn(
+			"Geocoding queue full, contact stays pending",
+			zap.Uint64("address_id", addressId),
+		)
+	}
+}
+
+// ResumePending queues every pending contact, blocking on a full queue
+// rather than dropping work. Run it in its own goroutine.
+func (s *geocodingService) ResumePending() {
+	ids, err := s.repo.FindPendingGeocodeIDs()
+	if err != nil {
+		logger.Log.Error("Failed to load pending geocodes", zap.Error(err))
+		return
+	}
+
+	for _, id := range ids {
+		s.queue <- id
+	}
+}
+
+func (s *geocodingService) work() {
+	for id := range s.queue {
+		s.geocode(id)
+	}
+}
+
+func (s *geocodingService) geocode(id uint64) {
+	address, err := s.repo.FindByID(id)
+	if err != nil {
+		// Deleted since it was queued.
+		return
+	}
+
+	query := locationOf(address)
+
+	now := time.Now()
+	address.GeocodedAt = &now
+	address.GeocodeAccuracy = ""
+	address.Latitude = nil
+	address.Longitude = nil
+
+	if query.Empty() {
+		address.GeocodeStatus = model.GeocodeSkipped
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), geocodeTimeout)
+		result, err := s.geocoder.Geocode(ctx, query)
+		cancel()
+
+		switch {
+		case errors.Is(err, geocoding.ErrNotFound):
+			address.GeocodeStatus = model.GeocodeNotFound
+		case err != nil:
+			logger.Log.Error(
+				"Geocoding failed",
+				zap.Uint64("address_id", id),
+				zap.String("error", err.Error()),
+			)
+			address.GeocodeStatus = model.GeocodeFailed
+		default:
+			address.GeocodeStatus = model.GeocodeOK
+			address.GeocodeAccuracy = result.Accuracy
+			address.Latitude = &result.Latitude
+			address.Longitude = &result.Longitude
+		}
+	}
+
+	if err := s.repo.UpdateGeocode(address); err != nil {
+		logger.Log.Error(
+			"Failed to store geocode",
+			zap.Uint64("address_id", id),
+			zap.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"address-book-server/geocoding"
+	"address-book-server/model"
+
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGeocodingServiceEnqueue(t *testing.T) {
+	pune := geocoding.Result{Latitude: 18.5204, Longitude: 73.8567, Accuracy: geocoding.AccuracyCity}
+	fort := geocoding.Result{Latitude: 18.9322, Longitude: 72.8351, Accuracy: geocoding.AccuracyPostcode}
+	stale := 1.5
+
+	tests := []struct {
+		name     string
+		address  model.Address
+		err      error
+		status   string
+		accuracy string
+		location *geocoding.Result
+		queried  bool
+	}{
+		{
+			name:     "located by city",
+			address:  model.Address{City: "PUNE", Country: "India"},
+			status:   model.GeocodeOK,
+			accuracy: geocoding.AccuracyCity,
+			location: &pune,
+			queried:  true,
+		},
+		{
+			name:     "located by pincode",
+			address:  model.Address{AddressLine1: "1 Marine Drive", City: "Mumbai", Pincode: "400001"},
+			status:   model.GeocodeOK,
+			accuracy: geocoding.AccuracyPostcode,
+			location: &fort,
+			queried:  true,
+		},
+		{
+			name:    "not found clears old coordinates",
+			address: model.Address{City: "Atlantis", Latitude: &stale, Longitude: &stale, GeocodeAccuracy: geocoding.AccuracyCity},
+			status:  model.GeocodeNotFound,
+			queried: true,
+		},
+		{
+			name:    "provider failure",
+			address: model.Address{City: "Pune"},
+			err:     errors.New("provider unavailable"),
+			status:  model.GeocodeFailed,
+			queried: true,
+		},
+		{
+			name:    "empty location is skipped",
+			address: model.Address{FirstName: "Asha"},
+			status:  model.GeocodeSkipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geocoder := geocoding.NewFakeGeocoder(map[string]geocoding.Result{
+				"pune":   pune,
+				"400001": fort,
+			})
+			geocoder.Err = tt.err
+
+			tt.address.ID = 7
+			tt.address.GeocodeStatus = model.GeocodePending
+			repo := newFakeAddressRepository(tt.address)
+
+			NewGeocodingService(repo, geocoder, 1).Enqueue(7)
+
+			var got model.Address
+			select {
+			case got = <-repo.geocoded:
+			case <-time.After(5 * time.Second):
+				t.Fatal("contact was not geocoded")
+			}
+
+			if got.GeocodeStatus != tt.status {
+				t.Errorf("status = %q, want %q", got.GeocodeStatus, tt.status)
+			}
+			if got.GeocodeAccuracy != tt.accuracy {
+				t.Errorf("accuracy = %q, want %q", got.GeocodeAccuracy, tt.accuracy)
+			}
+			if got.GeocodedAt == nil {
+				t.Error("geocoded_at was not set")
+			}
+
+			if tt.location == nil {
+				if got.Latitude != nil || got.Longitude != nil {
+					t.Errorf("location = %v, %v, want none", got.Latitude, got.Longitude)
+				}
+			} else if got.Latitude == nil || got.Longitude == nil ||
+				*got.Latitude != tt.location.Latitude || *got.Longitude != tt.location.Longitude {
+				t.Errorf("location = %v, %v, want %v, %v", got.Latitude, got.Longitude, tt.location.Latitude, tt.location.Longitude)
+			}
+
+			if queried := len(geocoder.Queries()) > 0; queried != tt.queried {
+				t.Errorf("geocoder queried = %v, want %v", queried, tt.queried)
+			}
+		})
+	}
+}
+
+func TestGeocodingServiceSkipsDeletedContacts(t *testing.T) {
+	geocoder := geocoding.NewFakeGeocoder(nil)
+	repo := newFakeAddressRepository(model.Address{ID: 7, City: "Pune", IsDeleted: true})
+
+	NewGeocodingService(repo, geocoder, 1).Enqueue(7)
+
+	select {
+	case got := <-repo.geocoded:
+		t.Fatalf("deleted contact was geocoded with status %q", got.GeocodeStatus)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if len(geocoder.Queries()) != 0 {
+		t.Error("geocoder was queried for a deleted contact")
+	}
+}
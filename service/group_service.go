@@ -0,0 +1,350 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type GroupService interface {
+	Create(userId uint64, req *dto.CreateGroupRequest) (*dto.GroupResponse, error)
+	List(userId uint64) ([]dto.GroupResponse, error)
+	Update(id, userId uint64, req *dto.UpdateGroupRequest) error
+	Delete(id, userId uint64) error
+	AddMembers(id, userId uint64, req *dto.GroupMembersRequest) error
+	RemoveMembers(id, userId uint64, req *dto.GroupMembersRequest) error
+}
+
+type groupService struct {
+	repo        repository.GroupRepository
+	addressRepo repository.AddressRepository
+}
+
+func NewGroupService(repo repository.GroupRepository, addressRepo repository.AddressRepository) GroupService {
+	return &groupService{repo: repo, addressRepo: addressRepo}
+}
+
+func (s *groupService) Create(userId uint64, req *dto.CreateGroupRequest) (*dto.GroupResponse, error) {
+
+	logger.Log.Info(
+		"Creating group",
+		zap.Uint64("user_id", userId),
+		zap.String("name", req.Name),
+	)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, appError.NewValidationError(map[string]string{
+			"name": "This field is required",
+		})
+	}
+
+	if req.ParentID != nil {
+		if _, err := s.repo.FindByIDAndUser(*req.ParentID, userId); err != nil {
+			return nil, appError.NotFound(
+				"Parent group not found",
+				err,
+			)
+		}
+	}
+
+	group := model.ContactGroup{
+		UserID:   userId,
+		ParentID: req.ParentID,
+		Name:     name,
+	}
+
+	if err := s.repo.Create(&group); err != nil {
+
+		logger.Log.Error(
+			"Failed to create group",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create group",
+			err,
+		)
+	}
+
+	groups, err := s.repo.FindByUser(userId)
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to fetch groups",
+			err,
+		)
+	}
+
+	response := dto.GroupResponse{
+		Id:       group.ID,
+		Name:     group.Name,
+		ParentId: group.ParentID,
+		Path:     groupPath(group, groupsById(groups)),
+	}
+
+	return &response, nil
+}
+
+func (s *groupService) List(userId uint64) ([]dto.GroupResponse, error) {
+
+	groups, err := s.repo.FindByUser(userId)
+
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch groups",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch groups",
+			err,
+		)
+	}
+
+	byId := groupsById(groups)
+
+	response := make([]dto.GroupResponse, 0, len(groups))
+	for _, g := range groups {
+		response = append(response, dto.GroupResponse{
+			Id:       g.ID,
+			Name:     g.Name,
+			ParentId: g.ParentID,
+			Path:     groupPath(g, byId),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *groupService) Update(id, userId uint64, req *dto.UpdateGroupRequest) error {
+
+	logger.Log.Info(
+		"Updating group",
+		zap.Uint64("group_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	group, err := s.repo.FindByIDAndUser(id, userId)
+	if err != nil {
+		return appError.NotFound(
+			"Group not found",
+			err,
+		)
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return appError.NewValidationError(map[string]string{
+				"name": "This field is required",
+			})
+		}
+		group.Name = name
+	}
+
+	if req.MoveToRoot {
+		group.ParentID = nil
+		group.Parent = nil
+	} else if req.ParentID != nil {
+		if err := s.checkParent(group, *req.ParentID); err != nil {
+			return err
+		}
+		group.ParentID = req.ParentID
+		group.Parent = nil
+	}
+
+	if err := s.repo.Update(group); err != nil {
+
+		logger.Log.Error(
+			"Failed to update group",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to update group",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *groupService) Delete(id, userId uint64) error {
+
+	logger.Log.Info(
+		"Deleting group",
+		zap.Uint64("group_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	group, err := s.repo.FindByIDAndUser(id, userId)
+	if err != nil {
+		return appError.NotFound(
+			"Group not found",
+			err,
+		)
+	}
+
+	if err := s.repo.Delete(group); err != nil {
+
+		logger.Log.Error(
+			"Failed to delete group",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to delete group",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *groupService) AddMembers(id, userId uint64, req *dto.GroupMembersRequest) error {
+
+	logger.Log.Info(
+		"Adding group members",
+		zap.Uint64("group_id", id),
+		zap.Uint64("user_id", userId),
+		zap.Int("addresses", len(req.AddressIDs)),
+	)
+
+	addressIDs, err := s.checkMembers(id, userId, req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.AddMembers(id, addressIDs); err != nil {
+
+		logger.Log.Error(
+			"Failed to add group members",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to add group members",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *groupService) RemoveMembers(id, userId uint64, req *dto.GroupMembersRequest) error {
+
+	logger.Log.Info(
+		"Removing group members",
+		zap.Uint64("group_id", id),
+		zap.Uint64("user_id", userId),
+		zap.Int("addresses", len(req.AddressIDs)),
+	)
+
+	addressIDs, err := s.checkMembers(id, userId, req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveMembers(id, addressIDs); err != nil {
+
+		logger.Log.Error(
+			"Failed to remove group members",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to remove group members",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *groupService) checkParent(group *model.ContactGroup, parentId uint64) error {
+	if _, err := s.repo.FindByIDAndUser(parentId, group.UserID); err != nil {
+		return appError.NotFound(
+			"Parent group not found",
+			err,
+		)
+	}
+
+	subtree, err := s.repo.FindSubtreeIDs(group.ID, group.UserID)
+	if err != nil {
+		return appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	for _, id := range subtree {
+		if id == parentId {
+			return appError.BadRequest(
+				"A group cannot be nested inside itself or one of its subgroups",
+				nil,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *groupService) checkMembers(id, userId uint64, req *dto.GroupMembersRequest) ([]uint64, error) {
+	if _, err := s.repo.FindByIDAndUser(id, userId); err != nil {
+		return nil, appError.NotFound(
+			"Group not found",
+			err,
+		)
+	}
+
+	addressIDs := uniqueIDs(req.AddressIDs)
+
+	count, err := s.addressRepo.CountByIDsAndUser(addressIDs, userId)
+	if err != nil {
+		return nil, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	if count != int64(len(addressIDs)) {
+		return nil, appError.NotFound(
+			"One or more addresses not found",
+			nil,
+		)
+	}
+
+	return addressIDs, nil
+}
+
+func groupsById(groups []model.ContactGroup) map[uint64]model.ContactGroup {
+	byId := make(map[uint64]model.ContactGroup, len(groups))
+	for _, g := range groups {
+		byId[g.ID] = g
+	}
+	return byId
+}
+
+func groupPath(group model.ContactGroup, byId map[uint64]model.ContactGroup) string {
+	names := []string{group.Name}
+	visited := map[uint64]bool{group.ID: true}
+
+	for group.ParentID != nil {
+		parent, ok := byId[*group.ParentID]
+		if !ok || visited[parent.ID] {
+			break
+		}
+		visited[parent.ID] = true
+		names = append([]string{parent.Name}, names...)
+		group = parent
+	}
+
+	return strings.Join(names, " > ")
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"address-book-server/logger"
+
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
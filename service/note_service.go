@@ -0,0 +1,176 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/mapper"
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"go.uber.org/zap"
+)
+
+type NoteService interface {
+	Create(addressId, userId, bookId uint64, req *dto.NoteRequest) (*dto.NoteResponse, error)
+	List(addressId, userId, bookId uint64) ([]dto.NoteResponse, error)
+	Update(id, addressId, userId, bookId uint64, req *dto.NoteRequest) (*dto.NoteResponse, error)
+	Delete(id, addressId, userId, bookId uint64) error
+}
+
+type noteService struct {
+	repo        repository.NoteRepository
+	addressRepo repository.AddressRepository
+}
+
+func NewNoteService(repo repository.NoteRepository, addressRepo repository.AddressRepository) NoteService {
+	return &noteService{repo: repo, addressRepo: addressRepo}
+}
+
+func (s *noteService) Create(addressId, userId, bookId uint64, req *dto.NoteRequest) (*dto.NoteResponse, error) {
+
+	logger.Log.Info(
+		"Adding note",
+		zap.Uint64("address_id", addressId),
+		zap.Uint64("user_id", userId),
+	)
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, err
+	}
+
+	note := model.Note{
+		AddressID: addressId,
+		UserID:    userId,
+		Body:      req.Body,
+	}
+
+	if err := s.repo.Create(&note); err != nil {
+
+		logger.Log.Error(
+			"Failed to add note",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to add note",
+			err,
+		)
+	}
+
+	response := mapper.ToNoteResponse(note)
+
+	return &response, nil
+}
+
+func (s *noteService) List(addressId, userId, bookId uint64) ([]dto.NoteResponse, error) {
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.repo.FindByAddress(addressId, userId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch notes",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch notes",
+			err,
+		)
+	}
+
+	response := make([]dto.NoteResponse, 0, len(notes))
+	for _, n := range notes {
+		response = append(response, mapper.ToNoteResponse(n))
+	}
+
+	return response, nil
+}
+
+func (s *noteService) Update(id, addressId, userId, bookId uint64, req *dto.NoteRequest) (*dto.NoteResponse, error) {
+
+	logger.Log.Info(
+		"Updating note",
+		zap.Uint64("note_id", id),
+		zap.Uint64("address_id", addressId),
+		zap.Uint64("user_id", userId),
+	)
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return nil, err
+	}
+
+	note, err := s.repo.FindByIDAndAddress(id, addressId, userId)
+	if err != nil {
+		return nil, appError.NotFound(
+			"Note not found",
+			err,
+		)
+	}
+
+	note.Body = req.Body
+
+	if err := s.repo.Update(note); err != nil {
+
+		logger.Log.Error(
+			"Failed to update note",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to update note",
+			err,
+		)
+	}
+
+	response := mapper.ToNoteResponse(*note)
+
+	return &response, nil
+}
+
+func (s *noteService) Delete(id, addressId, userId, bookId uint64) error {
+
+	logger.Log.Info(
+		"Deleting note",
+		zap.Uint64("note_id", id),
+		zap.Uint64("address_id", addressId),
+		zap.Uint64("user_id", userId),
+	)
+
+	if err := ensureAddress(s.addressRepo, addressId, userId, bookId); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindByIDAndAddress(id, addressId, userId); err != nil {
+		return appError.NotFound(
+			"Note not found",
+			err,
+		)
+	}
+
+	if err := s.repo.SoftDelete(id, addressId, userId); err != nil {
+
+		logger.Log.Error(
+			"Failed to delete note",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to delete note",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// ensureAddress checks that the contact exists and belongs to the book in
+// scope, so notes and attachments follow the book's access rules.
+func ensureAddress(repo repository.AddressRepository, addressId, userId, bookId uint64) error {
+	_, err := findAddressInBook(repo, addressId, userId, bookId)
+	return err
+}
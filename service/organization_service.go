@@ -0,0 +1,528 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/repository"
+	"address-book-server/utils"
+
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const organizationInviteTTL = 7 * 24 * time.Hour
+
+type OrganizationService interface {
+	Create(userId uint64, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error)
+	List(userId uint64) ([]dto.OrganizationResponse, error)
+	Members(orgId uint64) ([]dto.OrganizationMemberResponse, error)
+	UpdateMember(orgId, memberId uint64, callerRole string, req *dto.UpdateMemberRequest) error
+	RemoveMember(orgId, memberId, userId uint64, callerRole string) error
+	Invite(orgId, inviterId uint64, callerRole string, req *dto.OrganizationInviteRequest) (*dto.OrganizationInviteResponse, error)
+	Invites(orgId uint64) ([]dto.OrganizationInviteResponse, error)
+	RevokeInvite(orgId, inviteId uint64) error
+	ReceivedInvites(email string) ([]dto.OrganizationInviteResponse, error)
+	AcceptInvite(userId uint64, email string, token string) (*dto.OrganizationResponse, error)
+	// Membership returns the organization's account ID and the caller's
+	// role in it, or an error when the caller is not a member.
+	Membership(orgId, userId uint64) (uint64, string, error)
+}
+
+type organizationService struct {
+	repo     repository.OrganizationRepository
+	userRepo repository.UserRepository
+}
+
+func NewOrganizationService(repo repository.OrganizationRepository, userRepo repository.UserRepository) OrganizationService {
+	return &organizationService{repo: repo, userRepo: userRepo}
+}
+
+func (s *organizationService) Create(userId uint64, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+
+	logger.Log.Info(
+		"Creating organization",
+		zap.Uint64("user_id", userId),
+		zap.String("name", req.Name),
+	)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, appError.NewValidationError(map[string]string{
+			"name": "This field is required",
+		})
+	}
+
+	suffix, err := utils.NewSecretToken()
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to create organization",
+			err,
+		)
+	}
+
+	// The account never signs in: its email is unroutable and no bcrypt
+	// hash can match an empty password hash.
+	account := model.User{
+		Email: "org-" + suffix[:24] + "@organizations.invalid",
+	}
+
+	org := model.Organization{
+		Name:      name,
+		CreatedBy: userId,
+	}
+
+	owner := model.OrganizationMember{
+		UserID: userId,
+		Role:   model.OrgRoleOwner,
+	}
+
+	if err := s.repo.Create(&org, &account, &owner); err != nil {
+
+		logger.Log.Error(
+			"Failed to create organization",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create organization",
+			err,
+		)
+	}
+
+	return &dto.OrganizationResponse{
+		Id:   org.ID,
+		Name: org.Name,
+		Role: owner.Role,
+	}, nil
+}
+
+func (s *organizationService) List(userId uint64) ([]dto.OrganizationResponse, error) {
+
+	members, err := s.repo.FindMembershipsByUser(userId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch organizations",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch organizations",
+			err,
+		)
+	}
+
+	response := make([]dto.OrganizationResponse, 0, len(members))
+	for _, m := range members {
+		response = append(response, dto.OrganizationResponse{
+			Id:   m.Organization.ID,
+			Name: m.Organization.Name,
+			Role: m.Role,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *organizationService) Members(orgId uint64) ([]dto.OrganizationMemberResponse, error) {
+
+	members, err := s.repo.FindMembers(orgId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch organization members",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch organization members",
+			err,
+		)
+	}
+
+	response := make([]dto.OrganizationMemberResponse, 0, len(members))
+	for _, m := range members {
+		response = append(response, dto.OrganizationMemberResponse{
+			Id:       m.ID,
+			UserId:   m.UserID,
+			Email:    m.User.Email,
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *organizationService) UpdateMember(orgId, memberId uint64, callerRole string, req *dto.UpdateMemberRequest) error {
+
+	logger.Log.Info(
+		"Updating organization member",
+		zap.Uint64("organization_id", orgId),
+		zap.Uint64("member_id", memberId),
+		zap.String("role", req.Role),
+	)
+
+	member, err := s.repo.FindMemberByID(memberId, orgId)
+	if err != nil {
+		return appError.NotFound(
+			"Member not found",
+			err,
+		)
+	}
+
+	// Callers can only manage members below them and cannot hand out a
+	// role above their own.
+	if model.OrgRoleRank(member.Role) >= model.OrgRoleRank(callerRole) ||
+		model.OrgRoleRank(req.Role) > model.OrgRoleRank(callerRole) {
+		return appError.Forbidden(
+			"You cannot change this member's role",
+			nil,
+		)
+	}
+
+	member.Role = req.Role
+
+	if err := s.repo.UpdateMember(member); err != nil {
+		return appError.Internal(
+			"Failed to update member",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *organizationService) RemoveMember(orgId, memberId, userId uint64, callerRole string) error {
+
+	logger.Log.Info(
+		"Removing organization member",
+		zap.Uint64("organization_id", orgId),
+		zap.Uint64("member_id", memberId),
+		zap.Uint64("user_id", userId),
+	)
+
+	member, err := s.repo.FindMemberByID(memberId, orgId)
+	if err != nil {
+		return appError.NotFound(
+			"Member not found",
+			err,
+		)
+	}
+
+	if member.Role == model.OrgRoleOwner {
+		return appError.BadRequest(
+			"The organization owner cannot be removed",
+			nil,
+		)
+	}
+
+	leaving := member.UserID == userId
+	if !leaving && (model.OrgRoleRank(callerRole) < model.OrgRoleRank(model.OrgRoleAdmin) ||
+		model.OrgRoleRank(member.Role) >= model.OrgRoleRank(callerRole)) {
+		return appError.Forbidden(
+			"You cannot remove this member",
+			nil,
+		)
+	}
+
+	if err := s.repo.DeleteMember(memberId, orgId); err != nil {
+		return appError.Internal(
+			"Failed to remove member",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *organizationService) Invite(orgId, inviterId uint64, callerRole string, req *dto.OrganizationInviteRequest) (*dto.OrganizationInviteResponse, error) {
+
+	logger.Log.Info(
+		"Inviting user to organization",
+		zap.Uint64("organization_id", orgId),
+		zap.Uint64("invited_by", inviterId),
+		zap.String("email", req.Email),
+		zap.String("role", req.Role),
+	)
+
+	if model.OrgRoleRank(req.Role) > model.OrgRoleRank(callerRole) {
+		return nil, appError.Forbidden(
+			"You cannot invite members with a role above your own",
+			nil,
+		)
+	}
+
+	org, err := s.repo.FindByID(orgId)
+	if err != nil {
+		return nil, appError.NotFound(
+			"Organization not found",
+			err,
+		)
+	}
+
+	if user, err := s.userRepo.FindByEmail(req.Email); err == nil {
+		if _, err := s.repo.FindMember(orgId, user.ID); err == nil {
+			return nil, appError.BadRequest(
+				req.Email+" is already a member of this organization",
+				nil,
+			)
+		}
+	}
+
+	_, err = s.repo.FindPendingInviteByEmail(orgId, req.Email)
+
+	if err == nil {
+		return nil, appError.BadRequest(
+			req.Email+" has already been invited",
+			nil,
+		)
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	token, err := utils.NewSecretToken()
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to create invitation",
+			err,
+		)
+	}
+
+	invite := model.OrganizationInvite{
+		OrganizationID: orgId,
+		Email:          strings.ToLower(req.Email),
+		InvitedBy:      inviterId,
+		Role:           req.Role,
+		Status:         model.ShareStatusPending,
+		TokenHash:      utils.HashSecretToken(token),
+		ExpiresAt:      time.Now().Add(organizationInviteTTL),
+	}
+
+	if err := s.repo.CreateInvite(&invite); err != nil {
+
+		logger.Log.Error(
+			"Failed to create invitation",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create invitation",
+			err,
+		)
+	}
+
+	go sendOrganizationInvitation(invite, org.Name, token)
+
+	response := toOrganizationInviteResponse(invite)
+
+	return &response, nil
+}
+
+func (s *organizationService) Invites(orgId uint64) ([]dto.OrganizationInviteResponse, error) {
+
+	invites, err := s.repo.FindInvites(orgId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch invitations",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch invitations",
+			err,
+		)
+	}
+
+	response := make([]dto.OrganizationInviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		response = append(response, toOrganizationInviteResponse(invite))
+	}
+
+	return response, nil
+}
+
+func (s *organizationService) RevokeInvite(orgId, inviteId uint64) error {
+
+	logger.Log.Info(
+		"Revoking organization invitation",
+		zap.Uint64("organization_id", orgId),
+		zap.Uint64("invite_id", inviteId),
+	)
+
+	invite, err := s.repo.FindInviteByID(inviteId, orgId)
+	if err != nil {
+		return appError.NotFound(
+			"Invitation not found",
+			err,
+		)
+	}
+
+	invite.Status = model.ShareStatusRevoked
+
+	if err := s.repo.UpdateInvite(invite); err != nil {
+		return appError.Internal(
+			"Failed to revoke invitation",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *organizationService) ReceivedInvites(email string) ([]dto.OrganizationInviteResponse, error) {
+
+	invites, err := s.repo.FindReceivedInvites(email)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch invitations",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch invitations",
+			err,
+		)
+	}
+
+	response := make([]dto.OrganizationInviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		r := toOrganizationInviteResponse(invite)
+		r.OrganizationName = invite.Organization.Name
+		response = append(response, r)
+	}
+
+	return response, nil
+}
+
+func (s *organizationService) AcceptInvite(userId uint64, email string, token string) (*dto.OrganizationResponse, error) {
+
+	logger.Log.Info(
+		"Accepting organization invitation",
+		zap.Uint64("user_id", userId),
+	)
+
+	invite, err := s.repo.FindPendingInviteByTokenHash(utils.HashSecretToken(token))
+	if err != nil {
+		return nil, appError.NotFound(
+			"Invitation not found or expired",
+			err,
+		)
+	}
+
+	if !strings.EqualFold(invite.Email, email) {
+		return nil, appError.Forbidden(
+			"This invitation was sent to a different email address",
+			nil,
+		)
+	}
+
+	if _, err := s.repo.FindMember(invite.OrganizationID, userId); err == nil {
+		return nil, appError.BadRequest(
+			"You are already a member of this organization",
+			nil,
+		)
+	}
+
+	now := time.Now()
+	invite.Status = model.ShareStatusAccepted
+	invite.AcceptedAt = &now
+
+	member := model.OrganizationMember{
+		OrganizationID: invite.OrganizationID,
+		UserID:         userId,
+		Role:           invite.Role,
+	}
+
+	if err := s.repo.AcceptInvite(invite, &member); err != nil {
+
+		logger.Log.Error(
+			"Failed to accept invitation",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to accept invitation",
+			err,
+		)
+	}
+
+	return &dto.OrganizationResponse{
+		Id:   invite.Organization.ID,
+		Name: invite.Organization.Name,
+		Role: member.Role,
+	}, nil
+}
+
+func (s *organizationService) Membership(orgId, userId uint64) (uint64, string, error) {
+	member, err := s.repo.FindMember(orgId, userId)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, "", appError.Forbidden(
+			"You are not a member of this organization",
+			err,
+		)
+	}
+
+	if err != nil {
+		return 0, "", appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	return member.Organization.AccountID, member.Role, nil
+}
+
+func toOrganizationInviteResponse(invite model.OrganizationInvite) dto.OrganizationInviteResponse {
+	return dto.OrganizationInviteResponse{
+		Id:             invite.ID,
+		OrganizationId: invite.OrganizationID,
+		Email:          invite.Email,
+		Role:           invite.Role,
+		Status:         invite.Status,
+		ExpiresAt:      invite.ExpiresAt,
+		AcceptedAt:     invite.AcceptedAt,
+	}
+}
+
+func sendOrganizationInvitation(invite model.OrganizationInvite, orgName string, token string) {
+	body := fmt.Sprintf(
+		"You have been invited to join %s as %s.\n\n"+
+			"Sign in with %s and accept the invitation with this token:\n\n%s\n\n"+
+			"POST %s/api/v1/organizations/invites/accept {\"token\": \"%s\"}\n\n"+
+			"The invitation expires on %s.",
+		orgName,
+		invite.Role,
+		invite.Email,
+		token,
+		os.Getenv("APP_BASE_URL"),
+		token,
+		invite.ExpiresAt.Format(time.RFC1123),
+	)
+
+	err := utils.SendEmail(invite.Email, "You have been invited to "+orgName, body)
+
+	if err != nil {
+		logger.Log.Error(
+			"Invitation email failed",
+			zap.Uint64("invite_id", invite.ID),
+			zap.String("error", err.Error()),
+		)
+	}
+}
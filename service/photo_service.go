@@ -0,0 +1,184 @@
+package service
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/repository"
+	"address-book-server/storage"
+	"address-book-server/utils"
+
+	"errors"
+	"fmt"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.uber.org/zap"
+)
+
+var allowedPhotoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type PhotoService interface {
+	Upload(id, userId, bookId uint64, data []byte) error
+	Get(id, userId, bookId uint64, thumbnail bool) ([]byte, string, error)
+	Delete(id, userId, bookId uint64) error
+}
+
+type photoService struct {
+	addressRepo repository.AddressRepository
+	store       storage.BlobStore
+}
+
+func NewPhotoService(addressRepo repository.AddressRepository, store storage.BlobStore) PhotoService {
+	return &photoService{addressRepo: addressRepo, store: store}
+}
+
+func (s *photoService) Upload(id, userId, bookId uint64, data []byte) error {
+
+	logger.Log.Info(
+		"Uploading contact photo",
+		zap.Uint64("address_id", id),
+		zap.Uint64("user_id", userId),
+		zap.Int("size", len(data)),
+	)
+
+	if _, err := findAddressInBook(s.addressRepo, id, userId, bookId); err != nil {
+		return err
+	}
+
+	contentType := mimetype.Detect(data).String()
+	if !allowedPhotoTypes[contentType] {
+		return appError.BadRequest(
+			"Unsupported image type: "+contentType,
+			nil,
+		)
+	}
+
+	thumbnail, err := utils.GenerateThumbnail(data, utils.ThumbnailSize)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to generate thumbnail",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.BadRequest(
+			"Invalid image",
+			err,
+		)
+	}
+
+	photoKey := fmt.Sprintf("photos/%d/%d/original", userId, id)
+	thumbnailKey := fmt.Sprintf("photos/%d/%d/thumbnail", userId, id)
+
+	if err := s.store.Put(photoKey, data); err != nil {
+		return s.storageError(err)
+	}
+
+	if err := s.store.Put(thumbnailKey, thumbnail); err != nil {
+		return s.storageError(err)
+	}
+
+	if err := s.addressRepo.UpdatePhoto(id, userId, photoKey, thumbnailKey, contentType); err != nil {
+
+		logger.Log.Error(
+			"Failed to save photo",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to save photo",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *photoService) Get(id, userId, bookId uint64, thumbnail bool) ([]byte, string, error) {
+
+	address, err := findAddressInBook(s.addressRepo, id, userId, bookId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if address.PhotoKey == "" {
+		return nil, "", appError.NotFound(
+			"Photo not found",
+			nil,
+		)
+	}
+
+	key, contentType := address.PhotoKey, address.PhotoContentType
+	if thumbnail {
+		key, contentType = address.ThumbnailKey, "image/jpeg"
+	}
+
+	data, err := s.store.Get(key)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, "", appError.NotFound(
+			"Photo not found",
+			err,
+		)
+	}
+	if err != nil {
+		return nil, "", s.storageError(err)
+	}
+
+	return data, contentType, nil
+}
+
+func (s *photoService) Delete(id, userId, bookId uint64) error {
+
+	logger.Log.Info(
+		"Deleting contact photo",
+		zap.Uint64("address_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	address, err := findAddressInBook(s.addressRepo, id, userId, bookId)
+	if err != nil {
+		return err
+	}
+
+	if address.PhotoKey == "" {
+		return appError.NotFound(
+			"Photo not found",
+			nil,
+		)
+	}
+
+	if err := s.addressRepo.UpdatePhoto(id, userId, "", "", ""); err != nil {
+		return appError.Internal(
+			"Failed to delete photo",
+			err,
+		)
+	}
+
+	for _, key := range []string{address.PhotoKey, address.ThumbnailKey} {
+		if err := s.store.Delete(key); err != nil {
+			logger.Log.Error(
+				"Failed to delete photo blob",
+				zap.String("key", key),
+				zap.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *photoService) storageError(err error) error {
+
+	logger.Log.Error(
+		"Blob storage failure",
+		zap.String("error", err.Error()),
+	)
+
+	return appError.Internal(
+		"Failed to access photo storage",
+		err,
+	)
+}
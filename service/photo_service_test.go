@@ -0,0 +1,226 @@
+package service
+
+import (
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"address-book-server/storage"
+	"address-book-server/utils"
+
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+const (
+	photoOwner = 1
+	photoBook  = 3
+	photoID    = 7
+)
+
+func newTestPhotoService() (PhotoService, *fakeAddressRepository, storage.BlobStore) {
+	repo := newFakeAddressRepository(model.Address{ID: photoID, UserID: photoOwner, BookID: photoBook})
+	store := storage.NewMemoryBlobStore()
+	return NewPhotoService(repo, store), repo, store
+}
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(width, height)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeGIF(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func assertAppError(t *testing.T, err error, status int, message string) {
+	t.Helper()
+
+	var appErr *appError.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("error = %v, want an AppError", err)
+	}
+	if appErr.StatusCode != status || appErr.Message != message {
+		t.Errorf("error = %d %q, want %d %q", appErr.StatusCode, appErr.Message, status, message)
+	}
+}
+
+func TestPhotoServiceUpload(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        func(t *testing.T) []byte
+		contentType string
+	}{
+		{"png", func(t *testing.T) []byte { return encodePNG(t, 300, 200) }, "image/png"},
+		{"jpeg", func(t *testing.T) []byte { return encodeJPEG(t, 200, 300) }, "image/jpeg"},
+		{"gif", func(t *testing.T) []byte { return encodeGIF(t, 64, 64) }, "image/gif"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, store := newTestPhotoService()
+			data := tt.data(t)
+
+			if err := service.Upload(photoID, photoOwner, photoBook, data); err != nil {
+				t.Fatalf("Upload returned error: %v", err)
+			}
+
+			address, _ := repo.FindByIDAndUser(photoID, photoOwner)
+			if address.PhotoContentType != tt.contentType {
+				t.Errorf("content type = %q, want %q", address.PhotoContentType, tt.contentType)
+			}
+			if address.PhotoKey != "photos/1/7/original" || address.ThumbnailKey != "photos/1/7/thumbnail" {
+				t.Errorf("keys = %q, %q", address.PhotoKey, address.ThumbnailKey)
+			}
+
+			original, contentType, err := service.Get(photoID, photoOwner, photoBook, false)
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if !bytes.Equal(original, data) || contentType != tt.contentType {
+				t.Errorf("original = %d bytes of %q, want the %d uploaded bytes of %q", len(original), contentType, len(data), tt.contentType)
+			}
+
+			thumbnail, contentType, err := service.Get(photoID, photoOwner, photoBook, true)
+			if err != nil {
+				t.Fatalf("Get thumbnail returned error: %v", err)
+			}
+			if contentType != "image/jpeg" {
+				t.Errorf("thumbnail content type = %q, want image/jpeg", contentType)
+			}
+
+			config, format, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail does not decode: %v", err)
+			}
+			if format != "jpeg" || config.Width != utils.ThumbnailSize || config.Height != utils.ThumbnailSize {
+				t.Errorf("thumbnail = %s %dx%d, want jpeg %dx%d", format, config.Width, config.Height, utils.ThumbnailSize, utils.ThumbnailSize)
+			}
+
+			if stored, _ := store.Get(address.ThumbnailKey); !bytes.Equal(stored, thumbnail) {
+				t.Error("stored thumbnail differs from the one served")
+			}
+		})
+	}
+}
+
+func TestPhotoServiceUploadRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		message string
+	}{
+		{"text", []byte("not an image at all"), "Unsupported image type: text/plain; charset=utf-8"},
+		{"pdf", []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"), "Unsupported image type: application/pdf"},
+		{"truncated png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "Invalid image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, store := newTestPhotoService()
+
+			err := service.Upload(photoID, photoOwner, photoBook, tt.data)
+			assertAppError(t, err, http.StatusBadRequest, tt.message)
+
+			address, _ := repo.FindByIDAndUser(photoID, photoOwner)
+			if address.PhotoKey != "" {
+				t.Errorf("photo key = %q, want none", address.PhotoKey)
+			}
+			if _, err := store.Get("photos/1/7/original"); !errors.Is(err, storage.ErrBlobNotFound) {
+				t.Errorf("original was stored: %v", err)
+			}
+		})
+	}
+}
+
+func TestPhotoServiceChecksContact(t *testing.T) {
+	data := encodePNG(t, 16, 16)
+
+	tests := []struct {
+		name   string
+		userId uint64
+		bookId uint64
+		id     uint64
+	}{
+		{"other book", photoOwner, photoBook + 1, photoID},
+		{"other user", photoOwner + 1, photoBook, photoID},
+		{"unknown contact", photoOwner, photoBook, photoID + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := newTestPhotoService()
+
+			err := service.Upload(tt.id, tt.userId, tt.bookId, data)
+			assertAppError(t, err, http.StatusNotFound, "Address not found")
+
+			_, _, err = service.Get(tt.id, tt.userId, tt.bookId, false)
+			assertAppError(t, err, http.StatusNotFound, "Address not found")
+
+			err = service.Delete(tt.id, tt.userId, tt.bookId)
+			assertAppError(t, err, http.StatusNotFound, "Address not found")
+		})
+	}
+}
+
+func TestPhotoServiceDelete(t *testing.T) {
+	service, repo, store := newTestPhotoService()
+
+	err := service.Delete(photoID, photoOwner, photoBook)
+	assertAppError(t, err, http.StatusNotFound, "Photo not found")
+
+	if err := service.Upload(photoID, photoOwner, photoBook, encodePNG(t, 32, 32)); err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+
+	if err := service.Delete(photoID, photoOwner, photoBook); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	address, _ := repo.FindByIDAndUser(photoID, photoOwner)
+	if address.PhotoKey != "" || address.ThumbnailKey != "" || address.PhotoContentType != "" {
+		t.Errorf("photo columns = %q, %q, %q, want none", address.PhotoKey, address.ThumbnailKey, address.PhotoContentType)
+	}
+
+	for _, key := range []string{"photos/1/7/original", "photos/1/7/thumbnail"} {
+		if _, err := store.Get(key); !errors.Is(err, storage.ErrBlobNotFound) {
+			t.Errorf("blob %s was not deleted: %v", key, err)
+		}
+	}
+
+	_, _, err = service.Get(photoID, photoOwner, photoBook, false)
+	assertAppError(t, err, http.StatusNotFound, "Photo not found")
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/postal"
+)
+
+// ReferenceService answers lookups against the bundled reference data and
+// describes the data the server runs with. It has no storage of its own.
+type ReferenceService interface {
+	LookupPostalCode(country, code string) (*dto.PostalCodeResponse, error)
+	Geocoder() *dto.GeocoderResponse
+}
+
+type referenceService struct {
+	geocoder         string
+	geocoderCoverage string
+}
+
+// NewReferenceService takes the name of the configured geocoder and its
+// coverage, as reported by geocoding.CoverageOf.
+func NewReferenceService(geocoder, geocoderCoverage string) ReferenceService {
+	return &referenceService{geocoder: geocoder, geocoderCoverage: geocoderCoverage}
+}
+
+// Geocoder tells clients whether contacts are located against a full
+// provider or only the bundled sample gazetteer, where most addresses end
+// up not_found or placed at their country.
+func (s *referenceService) Geocoder() *dto.GeocoderResponse {
+	return &dto.GeocoderResponse{
+		Provider: s.geocoder,
+		Coverage: s.geocoderCoverage,
+	}
+}
+
+// LookupPostalCode accepts the country as a code or a name, like contact
+// addresses do. Unless a full directory is loaded only the bundled sample
+// is searched, so a miss does not mean the code is invalid.
+func (s *referenceService) LookupPostalCode(country, code string) (*dto.PostalCodeResponse, error) {
+	c, ok := postal.LookupCountry(country)
+	if !ok {
+		return nil, appError.NotFound("Unknown country", nil)
+	}
+
+	places := postal.LookupPostalCode(c.Alpha2, code)
+	if len(places) == 0 {
+		if postal.Coverage() == postal.CoverageSample {
+			return nil, appError.NotFound("Postal code not in the sample dataset", nil)
+		}
+		return nil, appError.NotFound("Unknown postal code", nil)
+	}
+
+	response := &dto.PostalCodeResponse{
+		Country:     c.Name,
+		CountryCode: c.Alpha2,
+		PostalCode:  postal.NormalizePostalCode(c.Alpha2, code),
+		Coverage:    postal.Coverage(),
+		Places:      make([]dto.PostalPlaceResponse, 0, len(places)),
+	}
+
+	for _, p := range places {
+		response.Places = append(response.Places, dto.PostalPlaceResponse{
+			City:      p.City,
+			District:  p.District,
+			State:     p.State,
+			StateCode: p.StateCode,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/model"
+	"address-book-server/repository"
+	"address-book-server/utils"
+
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const shareInviteTTL = 7 * 24 * time.Hour
+
+type ShareService interface {
+	// Role returns the caller's role on the owner's address book, or an
+	// error when the caller has no access.
+	Role(userId, ownerId uint64) (string, error)
+	Invite(ownerId, inviterId uint64, req *dto.CreateShareRequest) (*dto.ShareResponse, error)
+	List(ownerId uint64) ([]dto.ShareResponse, error)
+	UpdateRole(id, ownerId uint64, req *dto.UpdateShareRequest) error
+	Revoke(id, ownerId uint64) error
+	Received(userId uint64, email string) ([]dto.ShareResponse, error)
+	Accept(userId uint64, email string, token string) (*dto.ShareResponse, error)
+}
+
+type shareService struct {
+	repo     repository.ShareRepository
+	userRepo repository.UserRepository
+}
+
+func NewShareService(repo repository.ShareRepository, userRepo repository.UserRepository) ShareService {
+	return &shareService{repo: repo, userRepo: userRepo}
+}
+
+func (s *shareService) Role(userId, ownerId uint64) (string, error) {
+	if userId == ownerId {
+		return model.RoleOwner, nil
+	}
+
+	share, err := s.repo.FindAccepted(ownerId, userId)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", appError.Forbidden(
+			"You do not have access to this address book",
+			err,
+		)
+	}
+
+	if err != nil {
+		return "", appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	return share.Role, nil
+}
+
+func (s *shareService) Invite(ownerId, inviterId uint64, req *dto.CreateShareRequest) (*dto.ShareResponse, error) {
+
+	logger.Log.Info(
+		"Inviting user to address book",
+		zap.Uint64("owner_id", ownerId),
+		zap.Uint64("invited_by", inviterId),
+		zap.String("email", req.Email),
+		zap.String("role", req.Role),
+	)
+
+	owner, err := s.userRepo.FindByID(ownerId)
+	if err != nil {
+		return nil, appError.NotFound(
+			"Address book owner not found",
+			err,
+		)
+	}
+
+	if strings.EqualFold(owner.Email, req.Email) {
+		return nil, appError.BadRequest(
+			"The owner already has access to this address book",
+			nil,
+		)
+	}
+
+	_, err = s.repo.FindActiveByOwnerAndEmail(ownerId, req.Email)
+
+	if err == nil {
+		return nil, appError.BadRequest(
+			"This address book is already shared with "+req.Email,
+			nil,
+		)
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	token, err := utils.NewSecretToken()
+	if err != nil {
+		return nil, appError.Internal(
+			"Failed to create invitation",
+			err,
+		)
+	}
+
+	share := model.AddressBookShare{
+		OwnerID:      ownerId,
+		InviteeEmail: strings.ToLower(req.Email),
+		InvitedBy:    inviterId,
+		Role:         req.Role,
+		Status:       model.ShareStatusPending,
+		TokenHash:    utils.HashSecretToken(token),
+		ExpiresAt:    time.Now().Add(shareInviteTTL),
+	}
+
+	if err := s.repo.Create(&share); err != nil {
+
+		logger.Log.Error(
+			"Failed to create invitation",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create invitation",
+			err,
+		)
+	}
+
+	go sendShareInvitation(share, owner.Email, token)
+
+	response := toShareResponse(share)
+
+	return &response, nil
+}
+
+func (s *shareService) List(ownerId uint64) ([]dto.ShareResponse, error) {
+
+	shares, err := s.repo.FindByOwner(ownerId)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch shares",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch shares",
+			err,
+		)
+	}
+
+	response := make([]dto.ShareResponse, 0, len(shares))
+	for _, share := range shares {
+		response = append(response, toShareResponse(share))
+	}
+
+	return response, nil
+}
+
+func (s *shareService) UpdateRole(id, ownerId uint64, req *dto.UpdateShareRequest) error {
+
+	logger.Log.Info(
+		"Updating share role",
+		zap.Uint64("share_id", id),
+		zap.Uint64("owner_id", ownerId),
+		zap.String("role", req.Role),
+	)
+
+	share, err := s.repo.FindByIDAndOwner(id, ownerId)
+	if err != nil {
+		return appError.NotFound(
+			"Share not found",
+			err,
+		)
+	}
+
+	share.Role = req.Role
+
+	if err := s.repo.Update(share); err != nil {
+		return appError.Internal(
+			"Failed to update share",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *shareService) Revoke(id, ownerId uint64) error {
+
+	logger.Log.Info(
+		"Revoking share",
+		zap.Uint64("share_id", id),
+		zap.Uint64("owner_id", ownerId),
+	)
+
+	share, err := s.repo.FindByIDAndOwner(id, ownerId)
+	if err != nil {
+		return appError.NotFound(
+			"Share not found",
+			err,
+		)
+	}
+
+	share.Status = model.ShareStatusRevoked
+
+	if err := s.repo.Update(share); err != nil {
+		return appError.Internal(
+			"Failed to revoke share",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *shareService) Received(userId uint64, email string) ([]dto.ShareResponse, error) {
+
+	shares, err := s.repo.FindReceived(userId, email)
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch shares",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch shares",
+			err,
+		)
+	}
+
+	response := make([]dto.ShareResponse, 0, len(shares))
+	for _, share := range shares {
+		r := toShareResponse(share)
+		r.OwnerEmail = share.Owner.Email
+		response = append(response, r)
+	}
+
+	return response, nil
+}
+
+func (s *shareService) Accept(userId uint64, email string, token string) (*dto.ShareResponse, error) {
+
+	logger.Log.Info(
+		"Accepting address book invitation",
+		zap.Uint64("user_id", userId),
+	)
+
+	share, err := s.repo.FindPendingByTokenHash(utils.HashSecretToken(token))
+	if err != nil {
+		return nil, appError.NotFound(
+			"Invitation not found or expired",
+			err,
+		)
+	}
+
+	if !strings.EqualFold(share.InviteeEmail, email) {
+		return nil, appError.Forbidden(
+			"This invitation was sent to a different email address",
+			nil,
+		)
+	}
+
+	now := time.Now()
+	share.GranteeID = &userId
+	share.Status = model.ShareStatusAccepted
+	share.AcceptedAt = &now
+
+	if err := s.repo.Update(share); err != nil {
+
+		logger.Log.Error(
+			"Failed to accept invitation",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to accept invitation",
+			err,
+		)
+	}
+
+	response := toShareResponse(*share)
+
+	return &response, nil
+}
+
+func toShareResponse(share model.AddressBookShare) dto.ShareResponse {
+	return dto.ShareResponse{
+		Id:           share.ID,
+		OwnerId:      share.OwnerID,
+		InviteeEmail: share.InviteeEmail,
+		GranteeId:    share.GranteeID,
+		Role:         share.Role,
+		Status:       share.Status,
+		ExpiresAt:    share.ExpiresAt,
+		AcceptedAt:   share.AcceptedAt,
+	}
+}
+
+func sendShareInvitation(share model.AddressBookShare, ownerEmail string, token string) {
+	body := fmt.Sprintf(
+		"%s has invited you to their address book as %s.\n\n"+
+			"Sign in with %s and accept the invitation with this token:\n\n%s\n\n"+
+			"POST %s/api/v1/shares/accept {\"token\": \"%s\"}\n\n"+
+			"The invitation expires on %s.",
+		ownerEmail,
+		share.Role,
+		share.InviteeEmail,
+		token,
+		os.Getenv("APP_BASE_URL"),
+		token,
+		share.ExpiresAt.Format(time.RFC1123),
+	)
+
+	err := utils.SendEmail(share.InviteeEmail, "You have been invited to an address book", body)
+
+	if err != nil {
+		logger.Log.Error(
+			"Invitation email failed",
+			zap.Uint64("share_id", share.ID),
+			zap.String("error", err.Error()),
+		)
+	}
+}
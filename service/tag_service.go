@@ -0,0 +1,327 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/logger"
+	"address-book-server/mapper"
+	"address-book-server/model"
+	"address-book-server/repository"
+	"address-book-server/utils"
+
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type TagService interface {
+	Create(userId uint64, req *dto.CreateTagRequest) (*dto.TagResponse, error)
+	List(userId uint64) ([]dto.TagResponse, error)
+	Update(id, userId uint64, req *dto.UpdateTagRequest) error
+	Delete(id, userId uint64) error
+	Assign(userId uint64, req *dto.BulkTagRequest) error
+	Unassign(userId uint64, req *dto.BulkTagRequest) error
+}
+
+type tagService struct {
+	repo        repository.TagRepository
+	addressRepo repository.AddressRepository
+}
+
+func NewTagService(repo repository.TagRepository, addressRepo repository.AddressRepository) TagService {
+	return &tagService{repo: repo, addressRepo: addressRepo}
+}
+
+func (s *tagService) Create(userId uint64, req *dto.CreateTagRequest) (*dto.TagResponse, error) {
+
+	logger.Log.Info(
+		"Creating tag",
+		zap.Uint64("user_id", userId),
+		zap.String("name", req.Name),
+	)
+
+	name := strings.TrimSpace(req.Name)
+
+	if err := s.validateName(name, userId, 0); err != nil {
+		return nil, err
+	}
+
+	tag := model.Tag{
+		UserID: userId,
+		Name:   name,
+		Color:  req.Color,
+	}
+
+	if err := s.repo.Create(&tag); err != nil {
+
+		// Another request created the same name since validateName.
+		if utils.IsUniqueViolation(err) {
+			return nil, appError.BadRequest(
+				"Tag already exists",
+				err,
+			)
+		}
+
+		logger.Log.Error(
+			"Failed to create tag",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to create tag",
+			err,
+		)
+	}
+
+	response := mapper.ToTagResponse(tag)
+
+	return &response, nil
+}
+
+func (s *tagService) List(userId uint64) ([]dto.TagResponse, error) {
+
+	tags, err := s.repo.FindByUser(userId)
+
+	if err != nil {
+
+		logger.Log.Error(
+			"Failed to fetch tags",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, appError.Internal(
+			"Failed to fetch tags",
+			err,
+		)
+	}
+
+	response := make([]dto.TagResponse, 0, len(tags))
+	for _, t := range tags {
+		response = append(response, mapper.ToTagResponse(t))
+	}
+
+	return response, nil
+}
+
+func (s *tagService) Update(id, userId uint64, req *dto.UpdateTagRequest) error {
+
+	logger.Log.Info(
+		"Updating tag",
+		zap.Uint64("tag_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	tag, err := s.repo.FindByIDAndUser(id, userId)
+	if err != nil {
+		return appError.NotFound(
+			"Tag not found",
+			err,
+		)
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+
+		if err := s.validateName(name, userId, tag.ID); err != nil {
+			return err
+		}
+
+		tag.Name = name
+	}
+	if req.Color != nil {
+		tag.Color = *req.Color
+	}
+
+	if err := s.repo.Update(tag); err != nil {
+
+		if utils.IsUniqueViolation(err) {
+			return appError.BadRequest(
+				"Tag already exists",
+				err,
+			)
+		}
+
+		logger.Log.Error(
+			"Failed to update tag",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to update tag",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *tagService) Delete(id, userId uint64) error {
+
+	logger.Log.Info(
+		"Deleting tag",
+		zap.Uint64("tag_id", id),
+		zap.Uint64("user_id", userId),
+	)
+
+	if _, err := s.repo.FindByIDAndUser(id, userId); err != nil {
+		return appError.NotFound(
+			"Tag not found",
+			err,
+		)
+	}
+
+	if err := s.repo.Delete(id, userId); err != nil {
+
+		logger.Log.Error(
+			"Failed to delete tag",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to delete tag",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *tagService) Assign(userId uint64, req *dto.BulkTagRequest) error {
+
+	logger.Log.Info(
+		"Assigning tags",
+		zap.Uint64("user_id", userId),
+		zap.Int("tags", len(req.TagIDs)),
+		zap.Int("addresses", len(req.AddressIDs)),
+	)
+
+	tagIDs, addressIDs, err := s.checkOwnership(userId, req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Assign(tagIDs, addressIDs); err != nil {
+
+		logger.Log.Error(
+			"Failed to assign tags",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to assign tags",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *tagService) Unassign(userId uint64, req *dto.BulkTagRequest) error {
+
+	logger.Log.Info(
+		"Removing tags",
+		zap.Uint64("user_id", userId),
+		zap.Int("tags", len(req.TagIDs)),
+		zap.Int("addresses", len(req.AddressIDs)),
+	)
+
+	tagIDs, addressIDs, err := s.checkOwnership(userId, req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Unassign(tagIDs, addressIDs); err != nil {
+
+		logger.Log.Error(
+			"Failed to remove tags",
+			zap.String("error", err.Error()),
+		)
+
+		return appError.Internal(
+			"Failed to remove tags",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *tagService) validateName(name string, userId, exceptId uint64) error {
+	if name == "" {
+		return appError.NewValidationError(map[string]string{
+			"name": "This field is required",
+		})
+	}
+
+	existing, err := s.repo.FindByNameAndUser(name, userId)
+
+	if err == nil && existing.ID != exceptId {
+		return appError.BadRequest(
+			"Tag already exists",
+			nil,
+		)
+	}
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *tagService) checkOwnership(userId uint64, req *dto.BulkTagRequest) ([]uint64, []uint64, error) {
+	tagIDs := uniqueIDs(req.TagIDs)
+	addressIDs := uniqueIDs(req.AddressIDs)
+
+	tagCount, err := s.repo.CountByIDsAndUser(tagIDs, userId)
+	if err != nil {
+		return nil, nil, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	if tagCount != int64(len(tagIDs)) {
+		return nil, nil, appError.NotFound(
+			"One or more tags not found",
+			nil,
+		)
+	}
+
+	addressCount, err := s.addressRepo.CountByIDsAndUser(addressIDs, userId)
+	if err != nil {
+		return nil, nil, appError.Internal(
+			"Internal server error",
+			err,
+		)
+	}
+
+	if addressCount != int64(len(addressIDs)) {
+		return nil, nil, appError.NotFound(
+			"One or more addresses not found",
+			nil,
+		)
+	}
+
+	return tagIDs, addressIDs, nil
+}
+
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	result := make([]uint64, 0, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"address-book-server/dto"
+	appError "address-book-server/error"
+	"address-book-server/model"
+	"address-book-server/repository"
+
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// racingTagRepository finds no tag by name, as if another request created
+// it between the check and the write, which then hits the unique index.
+type racingTagRepository struct {
+	repository.TagRepository
+	writeErr error
+}
+
+func (r *racingTagRepository) FindByNameAndUser(string, uint64) (*model.Tag, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *racingTagRepository) FindByIDAndUser(id, userID uint64) (*model.Tag, error) {
+	return &model.Tag{ID: id, UserID: userID, Name: "old"}, nil
+}
+
+func (r *racingTagRepository) Create(*model.Tag) error { return r.writeErr }
+
+func (r *racingTagRepository) Update(*model.Tag) error { return r.writeErr }
+
+func TestTagServiceUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"unique violation", &pgconn.PgError{Code: "23505"}, http.StatusBadRequest},
+		{"other failure", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		s := NewTagService(&racingTagRepository{writeErr: tt.err}, nil)
+		name := "Work"
+
+		_, createErr := s.Create(1, &dto.CreateTagRequest{Name: name})
+		updateErr := s.Update(2, 1, &dto.UpdateTagRequest{Name: &name})
+
+		for op, err := range map[string]error{"Create": createErr, "Update": updateErr} {
+			var appErr *appError.AppError
+			if !errors.As(err, &appErr) || appErr.StatusCode != tt.status {
+				t.Errorf("%s: %s error = %v, want status %d", tt.name, op, err, tt.status)
+				continue
+			}
+			if tt.status == http.StatusBadRequest && appErr.Message != "Tag already exists" {
+				t.Errorf("%s: %s message = %q, want %q", tt.name, op, appErr.Message, "Tag already exists")
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package storage
+
+import "errors"
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore persists opaque binary objects such as contact photos under
+// slash-separated keys.
+type BlobStore interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
@@ -5,14 +5,14 @@ import (
 	"encoding/csv"
 )
 
-func GenerateAddressCSV(fields []string, records []map[string]string) ([]byte, error) {
+func GenerateAddressCSV(fields []string, labels map[string]string, records []map[string]string) ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	writer := csv.NewWriter(buffer)
 
 	header := []string{}
 
 	for _, field := range fields {
-		header = append(header, AllowedAddressExportFields[field])
+		header = append(header, labels[field])
 	}
 
 	if err := writer.Write(header); err != nil {
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// ParseEventDate accepts either a full date (YYYY-MM-DD) or a yearless
+// month-day (--MM-DD, as in ISO 8601 and vCard) and returns its parts.
+func ParseEventDate(value string) (month, day int, year *int, err error) {
+	if strings.HasPrefix(value, "--") {
+		parsed, err := time.Parse("2006-01-02", "2000-"+strings.TrimPrefix(value, "--"))
+		if err != nil {
+			return 0, 0, nil, errors.New("invalid date")
+		}
+		return int(parsed.Month()), parsed.Day(), nil, nil
+	}
+
+	parsed, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return 0, 0, nil, errors.New("invalid date")
+	}
+
+	y := parsed.Year()
+	return int(parsed.Month()), parsed.Day(), &y, nil
+}
+
+// FormatEventDate is the inverse of ParseEventDate.
+func FormatEventDate(month, day int, year *int) string {
+	monthDay := twoDigits(month) + "-" + twoDigits(day)
+	if year == nil {
+		return "--" + monthDay
+	}
+	return strconv.Itoa(*year) + "-" + monthDay
+}
+
+// OccurrenceInYear returns the date a yearly event falls on in the given
+// year. Feb 29 events are observed on Feb 28 in non-leap years.
+func OccurrenceInYear(month, day, year int, loc *time.Location) time.Time {
+	if month == 2 && day == 29 && !IsLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
+
+// NextOccurrence returns the first occurrence of a yearly event on or after
+// the calendar day of from, wrapping into the next year when needed.
+func NextOccurrence(month, day int, from time.Time) time.Time {
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	next := OccurrenceInYear(month, day, today.Year(), today.Location())
+	if next.Before(today) {
+		next = OccurrenceInYear(month, day, today.Year()+1, today.Location())
+	}
+
+	return next
+}
+
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
@@ -3,10 +3,14 @@ package utils
 import (
 	"address-book-server/logger"
 	"address-book-server/model"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -34,11 +38,242 @@ func Connect() *gorm.DB {
 	return db
 }
 
+// IsUniqueViolation reports whether err is Postgres rejecting a write that
+// breaks a unique index, as when two requests race past the same existence
+// check.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 func PerformMigration(db *gorm.DB) {
-	err := db.AutoMigrate(&model.User{}, &model.Address{})
+	err := db.AutoMigrate(&model.User{}, &model.Address{}, &model.Tag{}, &model.ContactGroup{},
+		&model.AddressEmail{}, &model.AddressPhone{}, &model.PostalAddress{},
+		&model.CustomField{}, &model.CustomFieldValue{},
+		&model.Note{}, &model.Attachment{}, &model.ContactEvent{},
+		&model.AddressBookShare{}, &model.AddressBook{},
+		&model.Organization{}, &model.OrganizationMember{}, &model.OrganizationInvite{})
 
 	if err != nil {
 		logger.Log.Error("Migration failed : " + err.Error(), zap.Error(err), zap.Time("time", time.Now()))
 		panic("Migration failed")
 	}
-}
\ No newline at end of file
+
+	if err := migrateContactDetails(db); err != nil {
+		logger.Log.Error("Contact detail migration failed : " + err.Error(), zap.Error(err), zap.Time("time", time.Now()))
+		panic("Migration failed")
+	}
+
+	if err := migrateAddressBooks(db); err != nil {
+		logger.Log.Error("Address book migration failed : " + err.Error(), zap.Error(err), zap.Time("time", time.Now()))
+		panic("Migration failed")
+	}
+
+	if err := migrateSearchIndexes(db); err != nil {
+		logger.Log.Error("Search index migration failed : " + err.Error(), zap.Error(err), zap.Time("time", time.Now()))
+		panic("Migration failed")
+	}
+
+	if err := migrateTagIndexes(db); err != nil {
+		logger.Log.Error("Tag index migration failed : " + err.Error(), zap.Error(err), zap.Time("time", time.Now()))
+		panic("Migration failed")
+	}
+
+	if err := migrateSortIndexes(db); err != nil {
+		logger.Log.Error("Sort index migration failed : " + err.Error(), zap.Error(err), zap.Time("time", time.Now()))
+		panic("Migration failed")
+	}
+}
+
+// migrateContactDetails copies the flat email, phone and postal columns of
+// contacts created before the child collections existed into primary entries.
+// Contacts that already have entries are left untouched, so it is safe to run
+// on every start.
+func migrateContactDetails(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO address_emails (address_id, type, label, email, is_primary, created_at, updated_at)
+		SELECT a.id, 'other', '', a.email, true, a.created_at, a.updated_at FROM addresses a
+		WHERE a.email <> '' AND NOT EXISTS (SELECT 1 FROM address_emails e WHERE e.address_id = a.id)`,
+
+		`INSERT INTO address_phones (address_id, type, label, phone, is_primary, created_at, updated_at)
+		SELECT a.id, 'other', '', a.phone, true, a.created_at, a.updated_at FROM addresses a
+		WHERE COALESCE(a.phone, '') <> '' AND NOT EXISTS (SELECT 1 FROM address_phones p WHERE p.address_id = a.id)`,
+
+		`INSERT INTO postal_addresses (address_id, type, label, is_primary, address_line1, address_line2, city, state, country, pincode, created_at, updated_at)
+		SELECT a.id, 'other', '', true, a.address_line1, COALESCE(a.address_line2, ''), COALESCE(a.city, ''), COALESCE(a.state, ''),
+			COALESCE(a.country, ''), COALESCE(a.pincode, ''), a.created_at, a.updated_at FROM addresses a
+		WHERE a.address_line1 <> '' AND NOT EXISTS (SELECT 1 FROM postal_addresses p WHERE p.address_id = a.id)`,
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+// migrateAddressBooks gives every user with contacts a default book and moves
+// contacts that predate address books into it. Like migrateContactDetails it
+// only touches rows that still need it.
+func migrateAddressBooks(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO address_books (user_id, name, is_default, created_at, updated_at)
+		SELECT DISTINCT a.user_id, '` + model.DefaultAddressBookName + `', true, NOW(), NOW() FROM addresses a
+		WHERE NOT EXISTS (SELECT 1 FROM address_books b WHERE b.user_id = a.user_id AND b.is_default = true)
+		ON CONFLICT DO NOTHING`,
+
+		`UPDATE addresses SET book_id = b.id FROM address_books b
+		WHERE b.user_id = addresses.user_id AND b.is_default = true
+		AND (addresses.book_id IS NULL OR addresses.book_id = 0)`,
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateSearchIndexes adds the full-text search column and the trigram
+// indexes behind fuzzy search. The column is generated by Postgres, so it
+// stays current on every write without application code, and it is not part
+// of the Address model so AutoMigrate leaves it alone.
+func migrateSearchIndexes(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE addresses ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (` + AddressSearchVector + `) STORED`,
+
+		`CREATE INDEX IF NOT EXISTS idx_addresses_search_vector ON addresses USING GIN (search_vector)`,
+
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+
+		`CREATE INDEX IF NOT EXISTS idx_addresses_full_name_trgm ON addresses USING GIN (` + AddressFullName + ` gin_trgm_ops)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_addresses_email_trgm ON addresses USING GIN (email gin_trgm_ops)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_addresses_city_trgm ON addresses USING GIN (city gin_trgm_ops)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_addresses_name_key_trgm ON addresses USING GIN (name_key gin_trgm_ops)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_address_phones_digits_trgm ON address_phones USING GIN (digits gin_trgm_ops)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// duplicateTags selects, for every tag whose name differs only in case from
+// an older tag of the same user, its id and the id of the oldest one.
+const duplicateTags = `SELECT id, keep_id FROM (
+	SELECT id, MIN(id) OVER (PARTITION BY user_id, LOWER(name)) AS keep_id FROM tags
+) t WHERE id <> keep_id`
+
+// migrateTagIndexes makes tag names unique per user regardless of case, as
+// FindByNameAndUser compares them. Tags that already differ only in case
+// are merged into the oldest first, so the index can be built.
+func migrateTagIndexes(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO address_tags (address_id, tag_id)
+		SELECT at.address_id, d.keep_id FROM address_tags at JOIN (` + duplicateTags + `) d ON d.id = at.tag_id
+		ON CONFLICT DO NOTHING`,
+
+		`DELETE FROM address_tags WHERE tag_id IN (SELECT id FROM (` + duplicateTags + `) d)`,
+
+		`DELETE FROM tags WHERE id IN (SELECT id FROM (` + duplicateTags + `) d)`,
+
+		`DROP INDEX IF EXISTS idx_tags_user_name`,
+
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_lower_name ON tags (user_id, LOWER(name))`,
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// sortIndexPrefix names the indexes built by migrateSortIndexes.
+const (
+	sortIndexPrefix     = "idx_addresses_sort_"
+	maxIdentifierLength = 63
+)
+
+// sortIndexes returns, by name, an index per sort field on the columns a
+// page of a book is read in: the owner, the book, the sort key and the id
+// that breaks ties. Text keys are indexed as SortKey under the given
+// collation, which is part of the name, so changing SORT_COLLATION builds
+// new indexes instead of keeping ones the query no longer matches.
+func sortIndexes(collation string) map[string]string {
+	suffix := ""
+	if collation != "" {
+		suffix = "_" + strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return '_'
+		}, collation)
+	}
+
+	indexes := map[string]string{}
+	for field, text := range AllowedAddressSortFields {
+		if field == "id" {
+			continue
+		}
+
+		key := field
+		if text {
+			key = "(" + SortKey(field, collation) + ")"
+		}
+
+		// Postgres truncates longer names, and would then never match.
+		name := sortIndexPrefix + field + suffix
+		if len(name) > maxIdentifierLength {
+			name = name[:maxIdentifierLength]
+		}
+		indexes[name] = `CREATE INDEX IF NOT EXISTS "` + name + `" ON addresses (user_id, book_id, ` + key + `, id) WHERE is_deleted = false`
+	}
+
+	return indexes
+}
+
+// migrateSortIndexes lets keyset pagination of a book, in any single
+// direction, read rows in index order instead of sorting the whole book.
+// Sort indexes of an earlier collation are dropped.
+func migrateSortIndexes(db *gorm.DB) error {
+	indexes := sortIndexes(SortCollation())
+
+	var existing []string
+	if err := db.Raw("SELECT indexname FROM pg_indexes WHERE tablename = 'addresses'").Scan(&existing).Error; err != nil {
+		return err
+	}
+
+	for _, name := range existing {
+		if _, keep := indexes[name]; !keep && strings.HasPrefix(name, sortIndexPrefix) {
+			if err := db.Exec(`DROP INDEX IF EXISTS "` + name + `"`).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, statement := range indexes {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
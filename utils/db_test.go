@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSortIndexes(t *testing.T) {
+	indexes := sortIndexes("")
+	if len(indexes) != len(AllowedAddressSortFields)-1 {
+		t.Errorf("sortIndexes(\"\") = %d indexes, want one per sort field but id", len(indexes))
+	}
+
+	tests := map[string]string{
+		"idx_addresses_sort_created_at": `(user_id, book_id, created_at, id) WHERE is_deleted = false`,
+		"idx_addresses_sort_first_name": `(user_id, book_id, (LOWER(COALESCE(first_name, ''))), id)`,
+	}
+	for name, want := range tests {
+		if !strings.Contains(indexes[name], want) {
+			t.Errorf("index %s = %q, want it to contain %q", name, indexes[name], want)
+		}
+	}
+
+	collated := sortIndexes("und-x-icu")
+	want := `(LOWER(COALESCE(last_name, '')) COLLATE "und-x-icu")`
+	if statement := collated["idx_addresses_sort_last_name_und_x_icu"]; !strings.Contains(statement, want) {
+		t.Errorf("collated last_name index = %q, want it to contain %q", statement, want)
+	}
+
+	for name := range sortIndexes(strings.Repeat("x", 80)) {
+		if len(name) > maxIdentifierLength {
+			t.Errorf("index name %q is longer than Postgres keeps", name)
+		}
+	}
+}
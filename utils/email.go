@@ -65,4 +65,35 @@ func SendEmailWithCSV(to string, subject string, body string, filename string, c
 		[]string{to},
 		msg.Bytes(),
 	)
-}
\ No newline at end of file
+}
+func SendEmail(to string, subject string, body string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	senderEmail := os.Getenv("SMTP_SENDER_EMAIL")
+	senderPassword := os.Getenv("SMTP_APP_PASSWORD")
+
+	var msg bytes.Buffer
+
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", senderEmail))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body + "\r\n")
+
+	auth := smtp.PlainAuth(
+		"",
+		senderEmail,
+		senderPassword,
+		smtpHost,
+	)
+
+	return smtp.SendMail(
+		smtpHost + ":" + smtpPort,
+		auth,
+		senderEmail,
+		[]string{to},
+		msg.Bytes(),
+	)
+}
@@ -1,16 +1,28 @@
 package utils
 
+// CustomFieldExportPrefix marks export fields that refer to a user-defined
+// custom field key, e.g. "cf.region_code".
+const CustomFieldExportPrefix = "cf."
+
 var AllowedAddressExportFields = map[string]string{
-	"id":             "ID",
-	"user_id":        "User ID",
-	"first_name":     "First Name",
-	"last_name":      "Last Name",
-	"email":          "Email",
-	"phone":          "Phone",
-	"address_line1":  "Address Line 1",
-	"address_line2":  "Address Line 2",
-	"city":           "City",
-	"state":          "State",
-	"country":        "Country",
-	"pincode":        "Pincode",
-}
\ No newline at end of file
+	"id":               "ID",
+	"user_id":          "User ID",
+	"first_name":       "First Name",
+	"last_name":        "Last Name",
+	"email":            "Email",
+	"phone":            "Phone",
+	"phone_e164":       "Phone (E.164)",
+	"address_line1":    "Address Line 1",
+	"address_line2":    "Address Line 2",
+	"city":             "City",
+	"state":            "State",
+	"country":          "Country",
+	"pincode":          "Pincode",
+	"country_code":     "Country Code",
+	"state_code":       "State Code",
+	"postal_code":      "Postal Code",
+	"tags":             "Tags",
+	"emails":           "Emails",
+	"phones":           "Phones",
+	"postal_addresses": "Postal Addresses",
+}
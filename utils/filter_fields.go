@@ -0,0 +1,23 @@
+package utils
+
+import "address-book-server/filter"
+
+// AddressFilterFields lists the fields the address list filter expression
+// may reference. Postal fields are those of the primary postal address.
+var AddressFilterFields = map[string]filter.Field{
+	"id":           {Column: "addresses.id", Type: filter.NumberField},
+	"first_name":   {Column: "addresses.first_name", Type: filter.TextField},
+	"last_name":    {Column: "addresses.last_name", Type: filter.TextField},
+	"email":        {Column: "addresses.email", Type: filter.TextField},
+	"phone":        {Column: "addresses.phone", Type: filter.TextField},
+	"phone_e164":   {Column: "addresses.phone_e164", Type: filter.TextField},
+	"city":         {Column: "addresses.city", Type: filter.TextField},
+	"state":        {Column: "addresses.state", Type: filter.TextField},
+	"country":      {Column: "addresses.country", Type: filter.TextField},
+	"pincode":      {Column: "addresses.pincode", Type: filter.TextField},
+	"country_code": {Column: "addresses.country_code", Type: filter.TextField},
+	"state_code":   {Column: "addresses.state_code", Type: filter.TextField},
+	"postal_code":  {Column: "addresses.postal_code", Type: filter.TextField},
+	"created_at":   {Column: "addresses.created_at", Type: filter.TimeField},
+	"updated_at":   {Column: "addresses.updated_at", Type: filter.TimeField},
+}
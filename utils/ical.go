@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICalEvent is an all-day event that recurs every year on its start date.
+type ICalEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	// LastDayOfFebruary makes a Feb 29 event fall on Feb 28 in common years
+	// instead of being skipped, as a plain yearly rule would do.
+	LastDayOfFebruary bool
+}
+
+// GenerateICalendar renders the events as an RFC 5545 VCALENDAR document.
+func GenerateICalendar(name string, events []ICalEvent, now time.Time) []byte {
+	var buffer bytes.Buffer
+
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeICalLine(&buffer, "BEGIN:VCALENDAR")
+	writeICalLine(&buffer, "VERSION:2.0")
+	writeICalLine(&buffer, "PRODID:-//address-book-server//Contact Events//EN")
+	writeICalLine(&buffer, "CALSCALE:GREGORIAN")
+	writeICalLine(&buffer, "METHOD:PUBLISH")
+	writeICalLine(&buffer, "X-WR-CALNAME:"+escapeICalText(name))
+
+	for _, event := range events {
+		rule := "RRULE:FREQ=YEARLY"
+		if event.LastDayOfFebruary {
+			rule = "RRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"
+		}
+
+		writeICalLine(&buffer, "BEGIN:VEVENT")
+		writeICalLine(&buffer, "UID:"+event.UID)
+		writeICalLine(&buffer, "DTSTAMP:"+stamp)
+		writeICalLine(&buffer, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+		writeICalLine(&buffer, "DURATION:P1D")
+		writeICalLine(&buffer, rule)
+		writeICalLine(&buffer, "SUMMARY:"+escapeICalText(event.Summary))
+		writeICalLine(&buffer, "TRANSP:TRANSPARENT")
+		writeICalLine(&buffer, "END:VEVENT")
+	}
+
+	writeICalLine(&buffer, "END:VCALENDAR")
+
+	return buffer.Bytes()
+}
+
+func escapeICalText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeICalLine folds content lines longer than 75 octets without splitting
+// UTF-8 sequences and terminates them with CRLF.
+func writeICalLine(buffer *bytes.Buffer, line string) {
+	limit := 75
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		buffer.WriteString(line[:cut])
+		buffer.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+
+	buffer.WriteString(line)
+	buffer.WriteString("\r\n")
+}
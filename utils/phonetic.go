@@ -0,0 +1,613 @@
+package utils
+
+import "strings"
+
+// phoneticCodeLength is the length Double Metaphone and Soundex codes are
+// cut to, the customary four characters for both.
+const phoneticCodeLength = 4
+
+// PhoneticCodes are the sound-alike keys of a name.
+type PhoneticCodes struct {
+	Metaphone          string
+	MetaphoneAlternate string
+	Soundex            string
+}
+
+// NamePhoneticCodes encodes a name after running it through SearchKey, so
+// accents and, when enabled, other scripts are folded to plain Latin first.
+// Spaces and punctuation are ignored: "Mary Ann" is coded as "maryann".
+func NamePhoneticCodes(name string) PhoneticCodes {
+	word := phoneticLetters(SearchKey(name))
+	primary, alternate := DoubleMetaphone(word)
+
+	return PhoneticCodes{
+		Metaphone:          primary,
+		MetaphoneAlternate: alternate,
+		Soundex:            Soundex(word),
+	}
+}
+
+// phoneticLetters upper-cases s and keeps only the letters A to Z.
+func phoneticLetters(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return -1
+	}, s)
+}
+
+var soundexDigits = map[rune]byte{
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+// Soundex returns the American Soundex code of word, or "" when it has no
+// letters. H and W do not separate letters with the same digit, vowels do.
+func Soundex(word string) string {
+	word = phoneticLetters(word)
+	if word == "" {
+		return ""
+	}
+
+	code := []byte{word[0]}
+	last := soundexDigits[rune(word[0])]
+
+	for _, r := range word[1:] {
+		digit, ok := soundexDigits[r]
+
+		switch {
+		case ok && digit != last:
+			code = append(code, digit)
+			last = digit
+		case !ok && r != 'H' && r != 'W':
+			last = 0
+		}
+
+		if len(code) == phoneticCodeLength {
+			break
+		}
+	}
+
+	for len(code) < phoneticCodeLength {
+		code = append(code, '0')
+	}
+
+	return string(code)
+}
+
+// DoubleMetaphone returns the primary and alternate Double Metaphone codes
+// of word, following Lawrence Philips' published rules. The alternate code
+// equals the primary one when the name has a single likely pronunciation.
+func DoubleMetaphone(word string) (string, string) {
+	m := &metaphone{value: phoneticLetters(word)}
+	if m.value == "" {
+		return "", ""
+	}
+
+	m.slavoGermanic = strings.ContainsAny(m.value, "WK") ||
+		strings.Contains(m.value, "CZ") || strings.Contains(m.value, "WITZ")
+
+	index := 0
+	if m.contains(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		index = 1
+	}
+	if m.at(0) == 'X' {
+		m.add("S")
+		index = 1
+	}
+
+	for !m.complete() && index < len(m.value) {
+		switch m.at(index) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if index == 0 {
+				m.add("A")
+			}
+			index++
+		case 'B':
+			m.add("P")
+			index = m.skip(index, 'B')
+		case 'C':
+			index = m.handleC(index)
+		case 'D':
+			index = m.handleD(index)
+		case 'F':
+			m.add("F")
+			index = m.skip(index, 'F')
+		case 'G':
+			index = m.handleG(index)
+		case 'H':
+			index = m.handleH(index)
+		case 'J':
+			index = m.handleJ(index)
+		case 'K':
+			m.add("K")
+			index = m.skip(index, 'K')
+		case 'L':
+			index = m.handleL(index)
+		case 'M':
+			m.add("M")
+			if m.at(index+1) == 'M' ||
+				(m.contains(index-1, 3, "UMB") && (index+1 == m.lastIndex() || m.contains(index+2, 2, "ER"))) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			m.add("N")
+			index = m.skip(index, 'N')
+		case 'P':
+			if m.at(index+1) == 'H' {
+				m.add("F")
+				index += 2
+			} else {
+				m.add("P")
+				if m.contains(index+1, 1, "P", "B") {
+					index += 2
+				} else {
+					index++
+				}
+			}
+		case 'Q':
+			m.add("K")
+			index = m.skip(index, 'Q')
+		case 'R':
+			index = m.handleR(index)
+		case 'S':
+			index = m.handleS(index)
+		case 'T':
+			index = m.handleT(index)
+		case 'V':
+			m.add("F")
+			index = m.skip(index, 'V')
+		case 'W':
+			index = m.handleW(index)
+		case 'X':
+			index = m.handleX(index)
+		case 'Z':
+			index = m.handleZ(index)
+		default:
+			index++
+		}
+	}
+
+	return m.primary.String(), m.alternate.String()
+}
+
+type metaphone struct {
+	value         string
+	slavoGermanic bool
+	primary       strings.Builder
+	alternate     strings.Builder
+}
+
+// at returns the letter at i, or 0 outside the word.
+func (m *metaphone) at(i int) byte {
+	if i < 0 || i >= len(m.value) {
+		return 0
+	}
+	return m.value[i]
+}
+
+func (m *metaphone) lastIndex() int {
+	return len(m.value) - 1
+}
+
+func (m *metaphone) isVowel(i int) bool {
+	return strings.IndexByte("AEIOUY", m.at(i)) >= 0
+}
+
+// contains reports whether the length letters starting at start equal one
+// of options.
+func (m *metaphone) contains(start, length int, options ...string) bool {
+	if start < 0 || start+length > len(m.value) {
+		return false
+	}
+	target := m.value[start : start+length]
+	for _, option := range options {
+		if option == target {
+			return true
+		}
+	}
+	return false
+}
+
+// skip steps over a doubled letter.
+func (m *metaphone) skip(index int, letter byte) int {
+	if m.at(index+1) == letter {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) add(code string) {
+	m.addBoth(code, code)
+}
+
+func (m *metaphone) addBoth(primary, alternate string) {
+	m.addPrimary(primary)
+	m.addAlternate(alternate)
+}
+
+func (m *metaphone) addPrimary(code string) {
+	if room := phoneticCodeLength - m.primary.Len(); room > 0 {
+		m.primary.WriteString(code[:min(room, len(code))])
+	}
+}
+
+func (m *metaphone) addAlternate(code string) {
+	if room := phoneticCodeLength - m.alternate.Len(); room > 0 {
+		m.alternate.WriteString(code[:min(room, len(code))])
+	}
+}
+
+func (m *metaphone) complete() bool {
+	return m.primary.Len() >= phoneticCodeLength && m.alternate.Len() >= phoneticCodeLength
+}
+
+func (m *metaphone) handleC(index int) int {
+	switch {
+	case m.germanicCH(index):
+		m.add("K")
+		return index + 2
+	case index == 0 && m.contains(index, 6, "CAESAR"):
+		m.add("S")
+		return index + 2
+	case m.contains(index, 2, "CH"):
+		return m.handleCH(index)
+	case m.contains(index, 2, "CZ") && !m.contains(index-2, 4, "WICZ"):
+		m.addBoth("S", "X")
+		return index + 2
+	case m.contains(index+1, 3, "CIA"):
+		m.add("X")
+		return index + 3
+	case m.contains(index, 2, "CC") && !(index == 1 && m.at(0) == 'M'):
+		if m.contains(index+2, 1, "I", "E", "H") && !m.contains(index+2, 2, "HU") {
+			if (index == 1 && m.at(index-1) == 'A') || m.contains(index-1, 5, "UCCEE", "UCCES") {
+				m.add("KS")
+			} else {
+				m.add("X")
+			}
+			return index + 3
+		}
+		m.add("K")
+		return index + 2
+	case m.contains(index, 2, "CK", "CG", "CQ"):
+		m.add("K")
+		return index + 2
+	case m.contains(index, 2, "CI", "CE", "CY"):
+		if m.contains(index, 3, "CIO", "CIE", "CIA") {
+			m.addBoth("S", "X")
+		} else {
+			m.add("S")
+		}
+		return index + 2
+	}
+
+	m.add("K")
+	if m.contains(index+1, 1, "C", "K", "Q") && !m.contains(index+1, 2, "CE", "CI") {
+		return index + 2
+	}
+	return index + 1
+}
+
+// germanicCH matches the "ach" of names like Bacher and Macher.
+func (m *metaphone) germanicCH(index int) bool {
+	if m.contains(index, 4, "CHIA") {
+		return true
+	}
+	if index <= 1 || m.isVowel(index-2) || !m.contains(index-1, 3, "ACH") {
+		return false
+	}
+	next := m.at(index + 2)
+	return (next != 'I' && next != 'E') || m.contains(index-2, 6, "BACHER", "MACHER")
+}
+
+func (m *metaphone) handleCH(index int) int {
+	if index > 0 && m.contains(index, 4, "CHAE") {
+		m.addBoth("K", "X")
+		return index + 2
+	}
+
+	greek := index == 0 &&
+		(m.contains(index+1, 5, "HARAC", "HARIS") || m.contains(index+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+		!m.contains(0, 5, "CHORE")
+
+	germanic := m.contains(0, 3, "SCH") ||
+		m.contains(index-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		m.contains(index+2, 1, "T", "S") ||
+		((m.contains(index-1, 1, "A", "O", "U", "E") || index == 0) &&
+			(m.contains(index+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W") || index+1 == m.lastIndex()))
+
+	switch {
+	case greek || germanic:
+		m.add("K")
+	case index > 0 && m.contains(0, 2, "MC"):
+		m.add("K")
+	case index > 0:
+		m.addBoth("X", "K")
+	default:
+		m.add("X")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleD(index int) int {
+	switch {
+	case m.contains(index, 2, "DG"):
+		if m.contains(index+2, 1, "I", "E", "Y") {
+			m.add("J")
+			return index + 3
+		}
+		m.add("TK")
+		return index + 2
+	case m.contains(index, 2, "DT", "DD"):
+		m.add("T")
+		return index + 2
+	}
+	m.add("T")
+	return index + 1
+}
+
+func (m *metaphone) handleG(index int) int {
+	switch {
+	case m.at(index+1) == 'H':
+		return m.handleGH(index)
+	case m.at(index+1) == 'N':
+		switch {
+		case index == 1 && m.isVowel(0) && !m.slavoGermanic:
+			m.addBoth("KN", "N")
+		case !m.contains(index+2, 2, "EY") && !m.slavoGermanic:
+			m.addBoth("N", "KN")
+		default:
+			m.add("KN")
+		}
+		return index + 2
+	case m.contains(index+1, 2, "LI") && !m.slavoGermanic:
+		m.addBoth("KL", "L")
+		return index + 2
+	case index == 0 && (m.at(index+1) == 'Y' ||
+		m.contains(index+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		m.addBoth("K", "J")
+		return index + 2
+	case (m.contains(index+1, 2, "ER") || m.at(index+1) == 'Y') &&
+		!m.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!m.contains(index-1, 1, "E", "I") && !m.contains(index-1, 3, "RGY", "OGY"):
+		m.addBoth("K", "J")
+		return index + 2
+	case m.contains(index+1, 1, "E", "I", "Y") || m.contains(index-1, 4, "AGGI", "OGGI"):
+		switch {
+		case m.contains(0, 3, "SCH") || m.contains(index+1, 2, "ET"):
+			m.add("K")
+		case m.contains(index+1, 3, "IER"):
+			m.add("J")
+		default:
+			m.addBoth("J", "K")
+		}
+		return index + 2
+	}
+
+	m.add("K")
+	return m.skip(index, 'G')
+}
+
+func (m *metaphone) handleGH(index int) int {
+	switch {
+	case index > 0 && !m.isVowel(index-1):
+		m.add("K")
+	case index == 0:
+		if m.at(index+2) == 'I' {
+			m.add("J")
+		} else {
+			m.add("K")
+		}
+	case (index > 1 && m.contains(index-2, 1, "B", "H", "D")) ||
+		(index > 2 && m.contains(index-3, 1, "B", "H", "D")) ||
+		(index > 3 && m.contains(index-4, 1, "B", "H")):
+		// Silent, as in "Hugh" or "bough".
+	case index > 2 && m.at(index-1) == 'U' && m.contains(index-3, 1, "C", "G", "L", "R", "T"):
+		m.add("F")
+	case m.at(index-1) != 'I':
+		m.add("K")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleH(index int) int {
+	if (index == 0 || m.isVowel(index-1)) && m.isVowel(index+1) {
+		m.add("H")
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleJ(index int) int {
+	if m.contains(index, 4, "JOSE") {
+		if len(m.value) == 4 {
+			m.add("H")
+		} else {
+			m.addBoth("J", "H")
+		}
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		m.addBoth("J", "A")
+	case m.isVowel(index-1) && !m.slavoGermanic && (m.at(index+1) == 'A' || m.at(index+1) == 'O'):
+		m.addBoth("J", "H")
+	case index == m.lastIndex():
+		m.addPrimary("J")
+	case !m.contains(index+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.contains(index-1, 1, "S", "K", "L"):
+		m.add("J")
+	}
+	return m.skip(index, 'J')
+}
+
+func (m *metaphone) handleL(index int) int {
+	if m.at(index+1) != 'L' {
+		m.add("L")
+		return index + 1
+	}
+
+	spanish := (index == len(m.value)-3 && m.contains(index-1, 4, "ILLO", "ILLA", "ALLE")) ||
+		((m.contains(len(m.value)-2, 2, "AS", "OS") || m.contains(len(m.value)-1, 1, "A", "O")) &&
+			m.contains(index-1, 4, "ALLE"))
+
+	if spanish {
+		m.addPrimary("L")
+	} else {
+		m.add("L")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleR(index int) int {
+	if index == m.lastIndex() && !m.slavoGermanic &&
+		m.contains(index-2, 2, "IE") && !m.contains(index-4, 2, "ME", "MA") {
+		m.addAlternate("R")
+	} else {
+		m.add("R")
+	}
+	return m.skip(index, 'R')
+}
+
+func (m *metaphone) handleS(index int) int {
+	switch {
+	case m.contains(index-1, 3, "ISL", "YSL"):
+		return index + 1
+	case index == 0 && m.contains(index, 5, "SUGAR"):
+		m.addBoth("X", "S")
+		return index + 1
+	case m.contains(index, 2, "SH"):
+		if m.contains(index+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			m.add("S")
+		} else {
+			m.add("X")
+		}
+		return index + 2
+	case m.contains(index, 3, "SIO", "SIA") || m.contains(index, 4, "SIAN"):
+		if m.slavoGermanic {
+			m.add("S")
+		} else {
+			m.addBoth("S", "X")
+		}
+		return index + 3
+	case (index == 0 && m.contains(index+1, 1, "M", "N", "L", "W")) || m.contains(index+1, 1, "Z"):
+		m.addBoth("S", "X")
+		return m.skip(index, 'Z')
+	case m.contains(index, 2, "SC"):
+		return m.handleSC(index)
+	}
+
+	if index == m.lastIndex() && m.contains(index-2, 2, "AI", "OI") {
+		m.addAlternate("S")
+	} else {
+		m.add("S")
+	}
+	if m.contains(index+1, 1, "S", "Z") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleSC(index int) int {
+	switch {
+	case m.at(index+2) == 'H':
+		switch {
+		case m.contains(index+3, 2, "ER", "EN"):
+			m.addBoth("X", "SK")
+		case m.contains(index+3, 2, "OO", "UY", "ED", "EM"):
+			m.add("SK")
+		case index == 0 && !m.isVowel(3) && m.at(3) != 'W':
+			m.addBoth("X", "S")
+		default:
+			m.add("X")
+		}
+	case m.contains(index+2, 1, "I", "E", "Y"):
+		m.add("S")
+	default:
+		m.add("SK")
+	}
+	return index + 3
+}
+
+func (m *metaphone) handleT(index int) int {
+	switch {
+	case m.contains(index, 4, "TION") || m.contains(index, 3, "TIA", "TCH"):
+		m.add("X")
+		return index + 3
+	case m.contains(index, 2, "TH") || m.contains(index, 3, "TTH"):
+		if m.contains(index+2, 2, "OM", "AM") || m.contains(0, 3, "SCH") {
+			m.add("T")
+		} else {
+			m.addBoth("0", "T")
+		}
+		return index + 2
+	}
+
+	m.add("T")
+	if m.contains(index+1, 1, "T", "D") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleW(index int) int {
+	switch {
+	case m.contains(index, 2, "WR"):
+		m.add("R")
+		return index + 2
+	case index == 0 && (m.isVowel(index+1) || m.contains(index, 2, "WH")):
+		if m.isVowel(index + 1) {
+			m.addBoth("A", "F")
+		} else {
+			m.add("A")
+		}
+	case (index == m.lastIndex() && m.isVowel(index-1)) ||
+		m.contains(index-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || m.contains(0, 3, "SCH"):
+		m.addAlternate("F")
+	case m.contains(index, 4, "WICZ", "WITZ"):
+		m.addBoth("TS", "FX")
+		return index + 4
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleX(index int) int {
+	if index == 0 {
+		m.add("S")
+		return index + 1
+	}
+
+	french := index == m.lastIndex() &&
+		(m.contains(index-3, 3, "IAU", "EAU") || m.contains(index-2, 2, "AU", "OU"))
+	if !french {
+		m.add("KS")
+	}
+	if m.contains(index+1, 1, "C", "X") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleZ(index int) int {
+	if m.at(index+1) == 'H' {
+		m.add("J")
+		return index + 2
+	}
+
+	if m.contains(index+1, 2, "ZO", "ZI", "ZA") || (m.slavoGermanic && index > 0 && m.at(index-1) != 'T') {
+		m.addBoth("S", "TS")
+	} else {
+		m.add("S")
+	}
+	return m.skip(index, 'Z')
+}
@@ -0,0 +1,120 @@
+package utils
+
+import "testing"
+
+func TestDoubleMetaphone(t *testing.T) {
+	tests := []struct {
+		word      string
+		primary   string
+		alternate string
+	}{
+		{"Smith", "SM0", "XMT"},
+		{"Schmidt", "XMT", "SMT"},
+		{"Schneider", "XNTR", "SNTR"},
+		{"Thomas", "TMS", "TMS"},
+		{"Thumb", "0M", "TM"},
+		{"Catherine", "K0RN", "KTRN"},
+		{"Kathryn", "K0RN", "KTRN"},
+		{"Philip", "FLP", "FLP"},
+		{"Filip", "FLP", "FLP"},
+		{"Michael", "MKL", "MXL"},
+		{"Richard", "RXRT", "RKRT"},
+		{"Orchestra", "ARKS", "ARKS"},
+		{"Chianti", "KNT", "KNT"},
+		{"Bacchus", "PKS", "PKS"},
+		{"Caesar", "SSR", "SSR"},
+		{"Jose", "HS", "HS"},
+		{"Jones", "JNS", "ANS"},
+		{"Jackson", "JKSN", "AKSN"},
+		{"Jankelowicz", "JNKL", "ANKL"},
+		{"Xavier", "SF", "SFR"},
+		{"Rogier", "RJ", "RJR"},
+		{"Zhao", "J", "J"},
+		{"Knight", "NT", "NT"},
+		{"Wright", "RT", "RT"},
+		{"Hugh", "H", "H"},
+		{"McHugh", "MK", "MK"},
+		{"Dumb", "TM", "TM"},
+		{"Campbell", "KMPL", "KMPL"},
+		{"Cabrillo", "KPRL", "KPR"},
+		{"Gallegos", "KLKS", "KKS"},
+		{"Tagliaro", "TKLR", "TLR"},
+		{"Ghislane", "JLN", "JLN"},
+		{"Agnes", "AKNS", "ANS"},
+		{"Edge", "AJ", "AJ"},
+		{"Arnoff", "ARNF", "ARNF"},
+		{"Arnow", "ARN", "ARNF"},
+		{"Womo", "AM", "FM"},
+		{"Washington", "AXNK", "FXNK"},
+		{"Tymczak", "TMSK", "TMXK"},
+		{"Deutsch", "TTX", "TTX"},
+		{"Aubrey", "APR", "APR"},
+		{"smith", "SM0", "XMT"},
+		{"", "", ""},
+		{"123", "", ""},
+	}
+
+	for _, tt := range tests {
+		primary, alternate := DoubleMetaphone(tt.word)
+		if primary != tt.primary || alternate != tt.alternate {
+			t.Errorf("DoubleMetaphone(%q) = %q, %q, want %q, %q", tt.word, primary, alternate, tt.primary, tt.alternate)
+		}
+	}
+}
+
+func TestSoundex(t *testing.T) {
+	tests := map[string]string{
+		"Robert":     "R163",
+		"Rupert":     "R163",
+		"Rubin":      "R150",
+		"Ashcraft":   "A261",
+		"Ashcroft":   "A261",
+		"Tymczak":    "T522",
+		"Pfister":    "P236",
+		"Honeyman":   "H555",
+		"Washington": "W252",
+		"Gutierrez":  "G362",
+		"Jackson":    "J250",
+		"Lee":        "L000",
+		"lee":        "L000",
+		"":           "",
+		"42":         "",
+	}
+
+	for word, want := range tests {
+		if got := Soundex(word); got != want {
+			t.Errorf("Soundex(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestNamePhoneticCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		want PhoneticCodes
+	}{
+		{"Müller", PhoneticCodes{"MLR", "MLR", "M460"}},
+		{"Muller", PhoneticCodes{"MLR", "MLR", "M460"}},
+		{"José", PhoneticCodes{"HS", "HS", "J200"}},
+		{"Mac Caffrey", PhoneticCodes{"MKFR", "MKFR", "M216"}},
+		{"O'Brien", PhoneticCodes{"APRN", "APRN", "O165"}},
+		{"राहुल", PhoneticCodes{}},
+		{"王", PhoneticCodes{}},
+		{"Иван", PhoneticCodes{}},
+		{"", PhoneticCodes{}},
+	}
+
+	for _, tt := range tests {
+		if got := NamePhoneticCodes(tt.name); got != tt.want {
+			t.Errorf("NamePhoneticCodes(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNamePhoneticCodesTransliterated(t *testing.T) {
+	t.Setenv("SEARCH_TRANSLITERATE", "true")
+
+	if got, want := NamePhoneticCodes("राहुल"), NamePhoneticCodes("Rahul"); got != want || got.Metaphone == "" {
+		t.Errorf("NamePhoneticCodes(राहुल) = %+v, want the codes of Rahul, %+v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	SearchModeSubstring = "substring"
+	SearchModeFulltext  = "fulltext"
+	SearchModeFuzzy     = "fuzzy"
+)
+
+// DefaultFuzzyThreshold is the word similarity a fuzzy match needs unless
+// FUZZY_SEARCH_THRESHOLD or the request says otherwise.
+const DefaultFuzzyThreshold = 0.3
+
+// AddressFullName is the name expression fuzzy search matches against. The
+// trigram index is built on exactly this expression so the planner can use
+// it.
+const AddressFullName = "(coalesce(addresses.first_name, '') || ' ' || coalesce(addresses.last_name, ''))"
+
+// RelevanceSortField is a pseudo sort field holding the search score. It is
+// only available, and the default, in ranked search modes.
+const RelevanceSortField = "relevance"
+
+// DistanceSortField is a pseudo sort field holding the distance from the
+// near point. It is only available, and the default, in location searches.
+const DistanceSortField = "distance"
+
+// EarthRadiusKm is the mean earth radius used for haversine distances.
+const EarthRadiusKm = 6371.0088
+
+// TextSearchConfig is the text search configuration used for contacts.
+// "simple" lower-cases without stemming, which suits names and addresses.
+const TextSearchConfig = "simple"
+
+// AddressSearchDocument is the text shown in search highlights. It lists the
+// same columns as AddressSearchVector.
+const AddressSearchDocument = `concat_ws(' ', addresses.first_name, addresses.last_name, addresses.email, addresses.phone,
+	addresses.address_line1, addresses.address_line2, addresses.city, addresses.state, addresses.country, addresses.pincode)`
+
+// AddressSearchVector defines the generated search_vector column. Names rank
+// above email and phone, which rank above the postal fields.
+const AddressSearchVector = `setweight(to_tsvector('` + TextSearchConfig + `', coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') ||
+	setweight(to_tsvector('` + TextSearchConfig + `', coalesce(email, '') || ' ' || coalesce(phone, '')), 'B') ||
+	setweight(to_tsvector('` + TextSearchConfig + `', coalesce(address_line1, '') || ' ' || coalesce(address_line2, '')), 'C') ||
+	setweight(to_tsvector('` + TextSearchConfig + `', coalesce(city, '') || ' ' || coalesce(state, '') || ' ' || coalesce(country, '') || ' ' || coalesce(pincode, '')), 'D')`
+
+func FuzzyThreshold() float64 {
+	threshold, err := strconv.ParseFloat(os.Getenv("FUZZY_SEARCH_THRESHOLD"), 64)
+
+	if err != nil || threshold <= 0 || threshold > 1 {
+		return DefaultFuzzyThreshold
+	}
+
+	return threshold
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"address-book-server/model"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// searchKeyRevision changes whenever the normalization below does, so that
+// cmd/searchkeybackfill picks up rows keyed by an older scheme.
+const searchKeyRevision = "2"
+
+// combiningMarks are the accent blocks removed after NFKD. Marks from other
+// scripts, such as Devanagari vowel signs, carry meaning and are kept.
+var combiningMarks = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0300, Hi: 0x036F, Stride: 1},
+		{Lo: 0x1AB0, Hi: 0x1AFF, Stride: 1},
+		{Lo: 0x1DC0, Hi: 0x1DFF, Stride: 1},
+		{Lo: 0x20D0, Hi: 0x20FF, Stride: 1},
+		{Lo: 0xFE20, Hi: 0xFE2F, Stride: 1},
+	},
+}
+
+// TransliterateSearchKeys reports whether search keys are written in Latin
+// letters, set with SEARCH_TRANSLITERATE=true.
+func TransliterateSearchKeys() bool {
+	return os.Getenv("SEARCH_TRANSLITERATE") == "true"
+}
+
+// SearchKeyVersion identifies the normalization that produced a stored key.
+func SearchKeyVersion() string {
+	if TransliterateSearchKeys() {
+		return searchKeyRevision + "+latin"
+	}
+	return searchKeyRevision
+}
+
+// SearchKey normalizes text for accent and case insensitive matching:
+// compatibility decomposition, accent folding, case folding and collapsed
+// whitespace, so "Ｍüller" and "muller" produce the same key. Stored keys and
+// query keys must both come from here.
+func SearchKey(s string) string {
+	if TransliterateSearchKeys() {
+		s = TransliterateToLatin(s)
+	}
+
+	s = norm.NFKD.String(s)
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(combiningMarks, r) {
+			return -1
+		}
+		return r
+	}, s)
+	s = cases.Fold().String(s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ApplySearchKeys fills the normalized search columns of a contact and its
+// postal addresses, including the phonetic name codes, from their display
+// values.
+func ApplySearchKeys(address *model.Address) {
+	address.NameKey = SearchKey(address.FirstName + " " + address.LastName)
+	address.SearchKeyVersion = SearchKeyVersion()
+
+	first := NamePhoneticCodes(address.FirstName)
+	address.FirstNameMetaphone = first.Metaphone
+	address.FirstNameMetaphoneAlt = first.MetaphoneAlternate
+	address.FirstNameSoundex = first.Soundex
+
+	last := NamePhoneticCodes(address.LastName)
+	address.LastNameMetaphone = last.Metaphone
+	address.LastNameMetaphoneAlt = last.MetaphoneAlternate
+	address.LastNameSoundex = last.Soundex
+
+	for i := range address.PostalAddresses {
+		address.PostalAddresses[i].CityKey = SearchKey(address.PostalAddresses[i].City)
+		address.PostalAddresses[i].CountryKey = SearchKey(address.PostalAddresses[i].Country)
+	}
+}
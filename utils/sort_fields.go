@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"os"
+	"regexp"
+)
+
+// AllowedAddressSortFields lists the columns the address list can be sorted
+// by. The value reports whether the column holds text, which is compared
+// case-insensitively under SortCollation.
+var AllowedAddressSortFields = map[string]bool{
+	"id":         false,
+	"first_name": true,
+	"last_name":  true,
+	"email":      true,
+	"phone":      true,
+	"city":       true,
+	"state":      true,
+	"country":    true,
+	"pincode":    true,
+	"created_at": false,
+	"updated_at": false,
+}
+
+const DefaultAddressSort = "-created_at"
+
+var collationName = regexp.MustCompile(`^[A-Za-z0-9_.@-]+$`)
+
+// SortCollation returns the PostgreSQL collation used for text sorting, such
+// as "und-x-icu" or "de_DE", from SORT_COLLATION. An empty or malformed
+// value falls back to the database default.
+func SortCollation() string {
+	collation := os.Getenv("SORT_COLLATION")
+
+	if !collationName.MatchString(collation) {
+		return ""
+	}
+
+	return collation
+}
+
+// SortKey is the expression a text sort field compares by: lower-cased,
+// with NULL as empty and under collation when one is set. The list query
+// and the sort indexes both build it here, so the indexes match it.
+func SortKey(expr, collation string) string {
+	expr = "LOWER(COALESCE(" + expr + ", ''))"
+	if collation != "" {
+		expr += ` COLLATE "` + collation + `"`
+	}
+	return expr
+}
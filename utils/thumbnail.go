@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+const ThumbnailSize = 128
+
+const maxImagePixels = 40_000_000
+
+// GenerateThumbnail center-crops the image to a square, box-filters it down to
+// size x size pixels and encodes the result as JPEG. Transparent areas are
+// flattened onto white since JPEG has no alpha channel.
+func GenerateThumbnail(data []byte, size int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, errors.New("image dimensions not supported")
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+	scale := float64(side) / float64(size)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		sy0 := y0 + int(float64(y)*scale)
+		sy1 := max(y0+int(float64(y+1)*scale), sy0+1)
+
+		for x := 0; x < size; x++ {
+			sx0 := x0 + int(float64(x)*scale)
+			sx1 := max(x0+int(float64(x+1)*scale), sx0+1)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			r, g, b, a = r/n, g/n, b/n, a/n
+			background := 0xffff - a
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r + background),
+				G: uint16(g + background),
+				B: uint16(b + background),
+				A: 0xffff,
+			})
+		}
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
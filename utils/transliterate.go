@@ -0,0 +1,105 @@
+package utils
+
+import "strings"
+
+// Devanagari to Latin follows the common ASCII spelling of Indian names
+// (sh, ch, aa written as a) rather than a scholarly scheme, since the goal is
+// to match what people type.
+var devanagariConsonants = map[rune]string{
+	'क': "k", 'ख': "kh", 'ग': "g", 'घ': "gh", 'ङ': "n",
+	'च': "ch", 'छ': "chh", 'ज': "j", 'झ': "jh", 'ञ': "n",
+	'ट': "t", 'ठ': "th", 'ड': "d", 'ढ': "dh", 'ण': "n",
+	'त': "t", 'थ': "th", 'द': "d", 'ध': "dh", 'न': "n", 'ऩ': "n",
+	'प': "p", 'फ': "ph", 'ब': "b", 'भ': "bh", 'म': "m",
+	'य': "y", 'र': "r", 'ऱ': "r", 'ल': "l", 'ळ': "l", 'ऴ': "l", 'व': "v",
+	'श': "sh", 'ष': "sh", 'स': "s", 'ह': "h",
+	'\u0958': "q", '\u0959': "kh", '\u095A': "gh", '\u095B': "z", '\u095C': "r", '\u095D': "rh", '\u095E': "f", '\u095F': "y",
+}
+
+// devanagariNukta covers consonants written as base letter plus nukta.
+var devanagariNukta = map[rune]string{
+	'क': "q", 'ख': "kh", 'ग': "gh", 'ज': "z", 'ड': "r", 'ढ': "rh", 'फ': "f", 'य': "y",
+}
+
+var devanagariVowels = map[rune]string{
+	'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u",
+	'ऋ': "ri", 'ॠ': "ri", 'ऌ': "li", 'ऍ': "e", 'ऎ': "e", 'ए': "e", 'ऐ': "ai",
+	'ऑ': "o", 'ऒ': "o", 'ओ': "o", 'औ': "au",
+}
+
+var devanagariVowelSigns = map[rune]string{
+	'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u", 'ृ': "ri", 'ॄ': "ri", 'ॢ': "li",
+	'ॅ': "e", 'ॆ': "e", 'े': "e", 'ै': "ai", 'ॉ': "o", 'ॊ': "o", 'ो': "o", 'ौ': "au",
+}
+
+var devanagariSigns = map[rune]string{
+	'ँ': "n", 'ं': "n", 'ः': "h", 'ऽ': "", '।': " ", '॥': " ",
+}
+
+const (
+	devanagariVirama    = '्'
+	devanagariNuktaSign = '़'
+)
+
+// TransliterateToLatin rewrites Devanagari text in Latin letters and leaves
+// everything else untouched. Consonants carry an inherent "a" unless a vowel
+// sign or virama follows; it is dropped at the end of a word, as in
+// "राहुल" -> "rahul".
+func TransliterateToLatin(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		consonant, ok := devanagariConsonants[r]
+		if !ok {
+			switch {
+			case devanagariVowels[r] != "":
+				b.WriteString(devanagariVowels[r])
+			case devanagariVowelSigns[r] != "":
+				b.WriteString(devanagariVowelSigns[r])
+			case r >= '०' && r <= '९':
+				b.WriteRune('0' + (r - '०'))
+			default:
+				if sign, ok := devanagariSigns[r]; ok {
+					b.WriteString(sign)
+				} else if r != devanagariVirama && r != devanagariNuktaSign {
+					b.WriteRune(r)
+				}
+			}
+			continue
+		}
+
+		if i+1 < len(runes) && runes[i+1] == devanagariNuktaSign {
+			if nukta, ok := devanagariNukta[r]; ok {
+				consonant = nukta
+			}
+			i++
+		}
+		b.WriteString(consonant)
+
+		if i+1 >= len(runes) {
+			break
+		}
+
+		next := runes[i+1]
+		switch {
+		case next == devanagariVirama:
+			i++
+		case devanagariVowelSigns[next] != "":
+			b.WriteString(devanagariVowelSigns[next])
+			i++
+		case isDevanagariLetter(next) || devanagariSigns[next] == "n" || devanagariSigns[next] == "h":
+			b.WriteString("a")
+		}
+	}
+
+	return b.String()
+}
+
+func isDevanagariLetter(r rune) bool {
+	_, consonant := devanagariConsonants[r]
+	_, vowel := devanagariVowels[r]
+	return consonant || vowel
+}
@@ -2,9 +2,13 @@ package utils
 
 import (
 	// "strings"
+	"reflect"
+
 	"github.com/go-playground/validator/v10"
 )
 
+// FormatValidationErrors maps each failed field to a message. Keys are the
+// field names validator.InitValidator reports, which are the JSON names.
 func FormatValidationErrors(err error) map[string]string {
 	errors := make(map[string]string)
 
@@ -18,14 +22,23 @@ func FormatValidationErrors(err error) map[string]string {
 
 		switch fieldErr.Tag() {
 
-		case "required":
+		case "required", "required_without", "required_if":
 			errors[field] = "This field is required"
 
+		case "phone":
+			errors[field] = "Invalid phone number"
+
 		case "email":
 			errors[field] = "Invalid email format"
 
 		case "min":
-			errors[field] = "Must be at least " + fieldErr.Param() + " characters long"
+			errors[field] = "Must be at least " + fieldErr.Param() + sizeUnit(fieldErr.Kind(), fieldErr.Param())
+
+		case "max":
+			errors[field] = "Must be at most " + fieldErr.Param() + sizeUnit(fieldErr.Kind(), fieldErr.Param())
+
+		case "oneof":
+			errors[field] = "Must be one of: " + fieldErr.Param()
 
 		case "password":
 			errors[field] = "Password must be at least 8 characters and include uppercase, lowercase, number, and special character"
@@ -37,3 +50,20 @@ func FormatValidationErrors(err error) map[string]string {
 
 	return errors
 }
+
+// sizeUnit is what min and max count for a field of the given kind: the
+// items of a collection, the value of a number and the characters of text.
+func sizeUnit(kind reflect.Kind, param string) string {
+	switch kind {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if param == "1" {
+			return " item"
+		}
+		return " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return ""
+	}
+	return " characters long"
+}
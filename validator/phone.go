@@ -1,22 +1,14 @@
 package validator
 
 import (
-	"unicode"
+	"address-book-server/phone"
+
 	"github.com/go-playground/validator/v10"
 )
 
+// PhoneValidator accepts anything shaped like a phone number in some
+// country. The country specific rules need the contact's address, so the
+// address service applies them.
 func PhoneValidator(fl validator.FieldLevel) bool {
-	phone := fl.Field().String()
-
-	if len(phone) < 10 {
-		return false
-	}
-
-	for _, ch := range phone {
-		if !unicode.IsDigit(ch) {
-			return false
-		}
-	}
-
-	return true
+	return phone.Plausible(fl.Field().String())
 }
@@ -1,12 +1,29 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
 
 var Validate *validator.Validate
 
+// InitValidator sets up the shared validator. Fields are reported by their
+// JSON name, so every validation error in the API is keyed by the name the
+// client sent: tag_ids rather than the snake-cased Go name tag_i_ds. Fields
+// without a JSON name keep their Go name.
 func InitValidator() {
 	Validate = validator.New()
 
+	Validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+
 	Validate.RegisterValidation("password", PasswordValidator)
 	Validate.RegisterValidation("phone", PhoneValidator)
-}
\ No newline at end of file
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"address-book-server/utils"
+
+	"testing"
+)
+
+type sample struct {
+	TagIDs  []uint64          `json:"tag_ids" validate:"required,max=2"`
+	Label   string            `json:"label" validate:"max=3"`
+	Limit   int               `json:"limit" validate:"max=5"`
+	Colors  map[string]string `validate:"min=1"`
+	Ignored string            `json:"-" validate:"required"`
+}
+
+func TestValidationErrorKeys(t *testing.T) {
+	InitValidator()
+
+	err := Validate.Struct(sample{
+		TagIDs: []uint64{1, 2, 3},
+		Label:  "long",
+		Limit:  9,
+		Colors: map[string]string{},
+	})
+
+	want := map[string]string{
+		"tag_ids": "Must be at most 2 items",
+		"label":   "Must be at most 3 characters long",
+		"limit":   "Must be at most 5",
+		"colors":  "Must be at least 1 item",
+		"ignored": "This field is required",
+	}
+
+	got := utils.FormatValidationErrors(err)
+	if len(got) != len(want) {
+		t.Errorf("FormatValidationErrors = %v, want %v", got, want)
+	}
+	for key, message := range want {
+		if got[key] != message {
+			t.Errorf("FormatValidationErrors[%q] = %q, want %q", key, got[key], message)
+		}
+	}
+}